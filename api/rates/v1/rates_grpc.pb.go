@@ -0,0 +1,328 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: rates.proto
+
+// Package rates.v1 is the gRPC API of the rates service. Costs are decimal
+// strings, such as "42000.5", so that no precision is lost.
+
+package ratesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RatesService_GetLastRates_FullMethodName   = "/rates.v1.RatesService/GetLastRates"
+	RatesService_GetMaxRates_FullMethodName    = "/rates.v1.RatesService/GetMaxRates"
+	RatesService_GetMinRates_FullMethodName    = "/rates.v1.RatesService/GetMinRates"
+	RatesService_GetAvgRates_FullMethodName    = "/rates.v1.RatesService/GetAvgRates"
+	RatesService_ActualizeRates_FullMethodName = "/rates.v1.RatesService/ActualizeRates"
+	RatesService_WatchRates_FullMethodName     = "/rates.v1.RatesService/WatchRates"
+)
+
+// RatesServiceClient is the client API for RatesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RatesServiceClient interface {
+	// GetLastRates returns the latest rates of titles, fetching the unknown ones.
+	GetLastRates(ctx context.Context, in *GetLastRatesRequest, opts ...grpc.CallOption) (*GetLastRatesResponse, error)
+	// GetMaxRates, GetMinRates and GetAvgRates aggregate the history of titles.
+	GetMaxRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error)
+	GetMinRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error)
+	GetAvgRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error)
+	// ActualizeRates fetches and stores the actual rates of every known title.
+	ActualizeRates(ctx context.Context, in *ActualizeRatesRequest, opts ...grpc.CallOption) (*ActualizeRatesResponse, error)
+	// WatchRates streams the rates of titles as they are stored. Streams that
+	// fall behind end with RESOURCE_EXHAUSTED and can be resumed with after.
+	WatchRates(ctx context.Context, in *WatchRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchRatesResponse], error)
+}
+
+type ratesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRatesServiceClient(cc grpc.ClientConnInterface) RatesServiceClient {
+	return &ratesServiceClient{cc}
+}
+
+func (c *ratesServiceClient) GetLastRates(ctx context.Context, in *GetLastRatesRequest, opts ...grpc.CallOption) (*GetLastRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLastRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetLastRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetMaxRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregateRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetMaxRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetMinRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregateRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetMinRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetAvgRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregateRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetAvgRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) ActualizeRates(ctx context.Context, in *ActualizeRatesRequest, opts ...grpc.CallOption) (*ActualizeRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActualizeRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_ActualizeRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) WatchRates(ctx context.Context, in *WatchRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchRatesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RatesService_ServiceDesc.Streams[0], RatesService_WatchRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRatesRequest, WatchRatesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_WatchRatesClient = grpc.ServerStreamingClient[WatchRatesResponse]
+
+// RatesServiceServer is the server API for RatesService service.
+// All implementations must embed UnimplementedRatesServiceServer
+// for forward compatibility.
+type RatesServiceServer interface {
+	// GetLastRates returns the latest rates of titles, fetching the unknown ones.
+	GetLastRates(context.Context, *GetLastRatesRequest) (*GetLastRatesResponse, error)
+	// GetMaxRates, GetMinRates and GetAvgRates aggregate the history of titles.
+	GetMaxRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error)
+	GetMinRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error)
+	GetAvgRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error)
+	// ActualizeRates fetches and stores the actual rates of every known title.
+	ActualizeRates(context.Context, *ActualizeRatesRequest) (*ActualizeRatesResponse, error)
+	// WatchRates streams the rates of titles as they are stored. Streams that
+	// fall behind end with RESOURCE_EXHAUSTED and can be resumed with after.
+	WatchRates(*WatchRatesRequest, grpc.ServerStreamingServer[WatchRatesResponse]) error
+	mustEmbedUnimplementedRatesServiceServer()
+}
+
+// UnimplementedRatesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRatesServiceServer struct{}
+
+func (UnimplementedRatesServiceServer) GetLastRates(context.Context, *GetLastRatesRequest) (*GetLastRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastRates not implemented")
+}
+func (UnimplementedRatesServiceServer) GetMaxRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaxRates not implemented")
+}
+func (UnimplementedRatesServiceServer) GetMinRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMinRates not implemented")
+}
+func (UnimplementedRatesServiceServer) GetAvgRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvgRates not implemented")
+}
+func (UnimplementedRatesServiceServer) ActualizeRates(context.Context, *ActualizeRatesRequest) (*ActualizeRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActualizeRates not implemented")
+}
+func (UnimplementedRatesServiceServer) WatchRates(*WatchRatesRequest, grpc.ServerStreamingServer[WatchRatesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRates not implemented")
+}
+func (UnimplementedRatesServiceServer) mustEmbedUnimplementedRatesServiceServer() {}
+func (UnimplementedRatesServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeRatesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RatesServiceServer will
+// result in compilation errors.
+type UnsafeRatesServiceServer interface {
+	mustEmbedUnimplementedRatesServiceServer()
+}
+
+func RegisterRatesServiceServer(s grpc.ServiceRegistrar, srv RatesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRatesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RatesService_ServiceDesc, srv)
+}
+
+func _RatesService_GetLastRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetLastRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetLastRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetLastRates(ctx, req.(*GetLastRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetMaxRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregateRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetMaxRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetMaxRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetMaxRates(ctx, req.(*GetAggregateRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetMinRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregateRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetMinRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetMinRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetMinRates(ctx, req.(*GetAggregateRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetAvgRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregateRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetAvgRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetAvgRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetAvgRates(ctx, req.(*GetAggregateRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_ActualizeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActualizeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).ActualizeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_ActualizeRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).ActualizeRates(ctx, req.(*ActualizeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_WatchRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RatesServiceServer).WatchRates(m, &grpc.GenericServerStream[WatchRatesRequest, WatchRatesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_WatchRatesServer = grpc.ServerStreamingServer[WatchRatesResponse]
+
+// RatesService_ServiceDesc is the grpc.ServiceDesc for RatesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RatesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rates.v1.RatesService",
+	HandlerType: (*RatesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLastRates",
+			Handler:    _RatesService_GetLastRates_Handler,
+		},
+		{
+			MethodName: "GetMaxRates",
+			Handler:    _RatesService_GetMaxRates_Handler,
+		},
+		{
+			MethodName: "GetMinRates",
+			Handler:    _RatesService_GetMinRates_Handler,
+		},
+		{
+			MethodName: "GetAvgRates",
+			Handler:    _RatesService_GetAvgRates_Handler,
+		},
+		{
+			MethodName: "ActualizeRates",
+			Handler:    _RatesService_ActualizeRates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRates",
+			Handler:       _RatesService_WatchRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rates.proto",
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// backfillBatch bounds the rates backfillRates stores at once.
+const backfillBatch = 1000
+
+// csvHeader is the first record of the files export writes and backfill reads.
+var csvHeader = []string{"title", "quote", "cost", "actual_at"}
+
+// exportRates writes the rates of titles in quote stored after the cursor
+// after as CSV, in the order they were stored. No titles means every title
+// stored in quote. It returns the cursor of the last rate written, or after if
+// there is none.
+func exportRates(
+	ctx context.Context,
+	out io.Writer,
+	storage cases.Storage,
+	titles []string,
+	quote string,
+	after entities.RateCursor,
+) (entities.RateCursor, error) {
+	quote, err := entities.NormalizeQuote(quote)
+	if err != nil {
+		return after, err
+	}
+
+	if len(titles) == 0 {
+		if titles, err = storage.GetCoinsList(ctx, quote); err != nil {
+			return after, err
+		}
+	}
+
+	w := csv.NewWriter(out)
+
+	if err = w.Write(csvHeader); err != nil {
+		return after, err
+	}
+
+	for len(titles) > 0 {
+		coins, err := storage.GetRatesAfter(ctx, titles, quote, after, cases.MaxRatesPage)
+		if err != nil {
+			return after, err
+		}
+
+		for _, coin := range coins {
+			record := []string{coin.Title, coin.Quote, coin.Cost.String(), coin.ActualAt.UTC().Format(time.RFC3339Nano)}
+			if err = w.Write(record); err != nil {
+				return after, err
+			}
+
+			after = entities.CursorOf(coin)
+		}
+
+		if len(coins) < cases.MaxRatesPage {
+			break
+		}
+	}
+
+	w.Flush()
+
+	return after, w.Error()
+}
+
+// backfillRates stores the rates of a CSV file written by exportRates and
+// returns how many it stored. Storages do not deduplicate rates: backfilling
+// a file twice stores its rates twice.
+func backfillRates(ctx context.Context, in io.Reader, storage cases.Storage) (int, error) {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = len(csvHeader)
+
+	header, err := r.Read()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	if !slices.Equal(header, csvHeader) {
+		return 0, fmt.Errorf("unexpected header %q, want %q", header, csvHeader)
+	}
+
+	stored := 0
+	batch := make([]*entities.Coin, 0, backfillBatch)
+
+	flush := func() error {
+		if err := storage.Store(ctx, batch); err != nil {
+			return err
+		}
+
+		stored += len(batch)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return stored, err
+		}
+
+		coin, err := parseRecord(record)
+		if err != nil {
+			line, _ := r.FieldPos(0)
+			return stored, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if batch = append(batch, coin); len(batch) == backfillBatch {
+			if err = flush(); err != nil {
+				return stored, err
+			}
+		}
+	}
+
+	return stored, flush()
+}
+
+func parseRecord(record []string) (*entities.Coin, error) {
+	quote, err := entities.NormalizeQuote(record[1])
+	if err != nil {
+		return nil, err
+	}
+
+	cost, err := decimal.NewFromString(record[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cost %q", record[2])
+	}
+
+	actualAt, err := time.Parse(time.RFC3339Nano, record[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid actual_at %q", record[3])
+	}
+
+	return entities.NewCoin(record[0], quote, cost, actualAt)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/entities"
+)
+
+func TestExportBackfill(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	base := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	source := memory.NewStorage()
+	require.NoError(t, source.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.RequireFromString("42000.5"), ActualAt: base},
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(2000), ActualAt: base.Add(time.Minute)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(43000), ActualAt: base.Add(2 * time.Minute)},
+		{Title: "BTC", Quote: "EUR", Cost: decimal.NewFromInt(39000), ActualAt: base},
+	}))
+
+	var out bytes.Buffer
+
+	last, err := exportRates(ctx, &out, source, nil, "usd", entities.RateCursor{})
+	require.NoError(t, err)
+	require.Equal(t, entities.RateCursor{Seq: 3}, last)
+	require.Equal(t, "title,quote,cost,actual_at\n"+
+		"BTC,USD,42000.5,2024-01-01T12:00:00Z\n"+
+		"ETH,USD,2000,2024-01-01T12:01:00Z\n"+
+		"BTC,USD,43000,2024-01-01T12:02:00Z\n", out.String())
+
+	target := memory.NewStorage()
+
+	stored, err := backfillRates(ctx, bytes.NewReader(out.Bytes()), target)
+	require.NoError(t, err)
+	require.Equal(t, 3, stored)
+
+	coins, err := target.GetActualCoin(ctx, []string{"BTC", "ETH"}, "USD")
+	require.NoError(t, err)
+	require.Len(t, coins, 2)
+
+	// An export after the last cursor has the rates stored since, even older ones.
+	require.NoError(t, source.Store(ctx, []*entities.Coin{
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(1900), ActualAt: base.Add(-time.Hour)},
+	}))
+
+	out.Reset()
+
+	last, err = exportRates(ctx, &out, source, []string{"BTC", "ETH"}, "USD", last)
+	require.NoError(t, err)
+	require.Equal(t, entities.RateCursor{Seq: 5}, last)
+	require.Equal(t, "title,quote,cost,actual_at\n"+
+		"ETH,USD,1900,2024-01-01T11:00:00Z\n", out.String())
+
+	out.Reset()
+
+	last, err = exportRates(ctx, &out, source, []string{"BTC", "ETH"}, "USD", last)
+	require.NoError(t, err)
+	require.Equal(t, entities.RateCursor{Seq: 5}, last)
+	require.Equal(t, "title,quote,cost,actual_at\n", out.String())
+}
+
+func TestBackfillErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "no header",
+			in:   "BTC,USD,1,2024-01-01T12:00:00Z\n",
+			want: "unexpected header",
+		},
+		{
+			name: "invalid cost",
+			in:   "title,quote,cost,actual_at\nBTC,USD,1,2024-01-01T12:00:00Z\nBTC,USD,lots,2024-01-01T12:00:00Z\n",
+			want: `line 3: invalid cost "lots"`,
+		},
+		{
+			name: "invalid time",
+			in:   "title,quote,cost,actual_at\nBTC,USD,1,yesterday\n",
+			want: `line 2: invalid actual_at "yesterday"`,
+		},
+		{
+			name: "missing field",
+			in:   "title,quote,cost,actual_at\nBTC,USD,1\n",
+			want: "wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := backfillRates(context.Background(), strings.NewReader(tt.in), memory.NewStorage())
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestRunRequiresPostgres(t *testing.T) {
+	t.Setenv("STORAGE", "memory")
+
+	for _, command := range []string{"backfill", "export"} {
+		err := run(context.Background(), options{}, command, nil)
+		require.EqualError(t, err, command+" requires STORAGE=postgres")
+	}
+}
+
+func TestRunMigrateDSN(t *testing.T) {
+	// migrate needs no app config, only a DSN.
+	t.Setenv("STORAGE", "memory")
+	t.Setenv("POSTGRES_DSN", "")
+
+	err := run(context.Background(), options{}, "migrate", []string{"version"})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "dsn not set")
+}
@@ -0,0 +1,192 @@
+// Command cryptoctl queries and maintains the rates from a terminal.
+//
+// Usage:
+//
+//	cryptoctl [flags] command [args]
+//
+// Commands:
+//
+//	last TITLE...                          latest rates
+//	max|min|avg [-from T] [-to T] [-window D] TITLE...
+//	                                       aggregate rates within a period
+//	actualize                              fetch fresh rates of every stored title
+//	migrate [-dsn DSN] [-steps N] up|down|version
+//	                                       manage the Postgres schema
+//	backfill [FILE]                        store the rates of a CSV file or stdin
+//	export [-after CURSOR] [TITLE...]      write the stored rates as CSV
+//
+// With -server the rates commands call the gRPC API of a running server.
+// Otherwise every command works on the storage and the providers configured
+// through the environment, as the server does, see app.LoadConfig. There
+// actualize only stores the rates: alerts are evaluated and webhooks sent by
+// the server, at its next actualization. backfill and export always work this
+// way, on STORAGE=postgres. migrate works like the migrate command, on the
+// database at -dsn, POSTGRES_DSN by default.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"crypto-project/internal/app"
+	"crypto-project/internal/entities"
+)
+
+type options struct {
+	server  string
+	quote   string
+	json    bool
+	timeout time.Duration
+}
+
+func main() {
+	var opts options
+
+	flag.StringVar(&opts.server, "server", "", "gRPC address of a running server, e.g. localhost:9090")
+	flag.StringVar(&opts.quote, "quote", "", "quote currency, "+entities.DefaultQuote+" by default")
+	flag.BoolVar(&opts.json, "json", false, "print the rates as JSON lines")
+	flag.DurationVar(&opts.timeout, "timeout", 30*time.Second, "timeout of the rates commands")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] "+
+			"last|max|min|avg|actualize|migrate|backfill|export [args]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, opts, flag.Arg(0), flag.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, opts options, command string, args []string) error {
+	switch command {
+	case "last", "max", "min", "avg", "actualize":
+		rates, closeRates, err := dialRates(ctx, opts.server)
+		if err != nil {
+			return err
+		}
+		defer closeRates()
+
+		ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+
+		return runRates(ctx, os.Stdout, rates, opts, command, args)
+	case "migrate":
+		if opts.server != "" {
+			return fmt.Errorf("%s cannot be run against a server", command)
+		}
+
+		return runMigrate(ctx, args)
+	case "backfill", "export":
+		if opts.server != "" {
+			return fmt.Errorf("%s cannot be run against a server", command)
+		}
+
+		cfg, err := app.LoadConfig(os.Getenv)
+		if err != nil {
+			return err
+		}
+
+		// A memory storage would start empty and be lost on exit.
+		if cfg.Storage != app.StoragePostgres {
+			return fmt.Errorf("%s requires STORAGE=%s", command, app.StoragePostgres)
+		}
+
+		storage, closeStorage, err := app.BuildStorage(ctx, cfg, nil)
+		if err != nil {
+			return err
+		}
+		defer closeStorage()
+
+		if command == "backfill" {
+			return runBackfill(ctx, storage, args)
+		}
+
+		return runExport(ctx, storage, opts, args)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+func runMigrate(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dsn := flags.String("dsn", os.Getenv("POSTGRES_DSN"), "postgres connection string")
+	steps := flags.Int("steps", 1, "number of migrations to roll back with down")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("migrate expects one of up, down or version")
+	}
+
+	return app.Migrate(ctx, os.Stdout, *dsn, flags.Arg(0), *steps)
+}
+
+func runBackfill(ctx context.Context, storage app.Storage, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("backfill expects at most one file")
+	}
+
+	var in io.Reader = os.Stdin
+
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		in = file
+	}
+
+	stored, err := backfillRates(ctx, in, storage)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "stored %d rates\n", stored)
+
+	return nil
+}
+
+func runExport(ctx context.Context, storage app.Storage, opts options, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	after := flags.String("after", "", "cursor of the last rate already exported")
+	_ = flags.Parse(args)
+
+	var cursor entities.RateCursor
+
+	if *after != "" {
+		var err error
+
+		if cursor, err = entities.ParseRateCursor(*after); err != nil {
+			return err
+		}
+	}
+
+	last, err := exportRates(ctx, os.Stdout, storage, splitTitles(flags.Args()), opts.quote, cursor)
+	if err != nil {
+		return err
+	}
+
+	// The cursor continues an incremental export.
+	if last.Seq != 0 {
+		fmt.Fprintf(os.Stderr, "last cursor: %s\n", last)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ratesv1 "crypto-project/api/rates/v1"
+	"crypto-project/internal/app"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// ratesClient is the part of cases.Service the rates commands use, served
+// either in process or by a remote server.
+type ratesClient interface {
+	GetLastRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error)
+	GetMaxRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetMinRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetAvgRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	ActualizeRates(ctx context.Context) error
+}
+
+var (
+	_ ratesClient = (*cases.Service)(nil)
+	_ ratesClient = (*remote)(nil)
+)
+
+// dialRates connects to the server at addr or, without one, builds the
+// service from the environment. That service has neither alerts nor events:
+// its ActualizeRates only fetches and stores rates.
+func dialRates(ctx context.Context, addr string) (ratesClient, func(), error) {
+	if addr != "" {
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &remote{client: ratesv1.NewRatesServiceClient(conn)}, func() { conn.Close() }, nil
+	}
+
+	cfg, err := app.LoadConfig(os.Getenv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	storage, closeStorage, err := app.BuildStorage(ctx, cfg, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider, err := app.BuildProvider(cfg, nil)
+	if err != nil {
+		closeStorage()
+		return nil, nil, err
+	}
+
+	service, err := cases.NewService(provider, storage)
+	if err != nil {
+		closeStorage()
+		return nil, nil, err
+	}
+
+	return service, closeStorage, nil
+}
+
+func runRates(ctx context.Context, out io.Writer, rates ratesClient, opts options, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+
+	var from, to, window string
+
+	if command != "last" && command != "actualize" {
+		flags.StringVar(&from, "from", "", "start of the period, RFC 3339")
+		flags.StringVar(&to, "to", "", "end of the period, RFC 3339")
+		flags.StringVar(&window, "window", "", "period ending now such as 24h, instead of from and to")
+	}
+
+	_ = flags.Parse(args)
+
+	titles := splitTitles(flags.Args())
+
+	var (
+		coins []*entities.Coin
+		err   error
+	)
+
+	switch command {
+	case "actualize":
+		return rates.ActualizeRates(ctx)
+	case "last":
+		coins, err = rates.GetLastRates(ctx, titles, opts.quote)
+	default:
+		period, periodErr := entities.ParsePeriod(from, to, window, time.Now())
+		if periodErr != nil {
+			return periodErr
+		}
+
+		get := map[string]func(context.Context, []string, string, entities.Period) ([]*entities.Coin, error){
+			cases.AggTypeMax: rates.GetMaxRates,
+			cases.AggTypeMin: rates.GetMinRates,
+			cases.AggTypeAvg: rates.GetAvgRates,
+		}[command]
+
+		coins, err = get(ctx, titles, opts.quote, period)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return printRates(out, coins, opts.json)
+}
+
+// splitTitles accepts titles as separate arguments as well as comma-separated.
+func splitTitles(args []string) []string {
+	titles := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		for _, title := range strings.Split(arg, ",") {
+			if title = strings.TrimSpace(title); title != "" {
+				titles = append(titles, title)
+			}
+		}
+	}
+
+	return titles
+}
+
+type rateJSON struct {
+	Title    string          `json:"title"`
+	Quote    string          `json:"quote"`
+	Cost     decimal.Decimal `json:"cost"`
+	ActualAt time.Time       `json:"actual_at"`
+}
+
+// printRates writes coins as a table or as JSON lines.
+func printRates(out io.Writer, coins []*entities.Coin, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(out)
+
+		for _, coin := range coins {
+			rate := rateJSON{Title: coin.Title, Quote: coin.Quote, Cost: coin.Cost, ActualAt: coin.ActualAt}
+			if err := encoder.Encode(rate); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "TITLE\tQUOTE\tCOST\tACTUAL AT")
+
+	for _, coin := range coins {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", coin.Title, coin.Quote, coin.Cost, coin.ActualAt.Local().Format(time.RFC3339))
+	}
+
+	return table.Flush()
+}
+
+// remote serves the rates commands through the gRPC API.
+type remote struct {
+	client ratesv1.RatesServiceClient
+}
+
+func (r *remote) GetLastRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	resp, err := r.client.GetLastRates(ctx, &ratesv1.GetLastRatesRequest{Titles: titles, Quote: quote})
+	if err != nil {
+		return nil, err
+	}
+
+	return newCoins(resp.GetRates())
+}
+
+func (r *remote) GetMaxRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error) {
+	return r.aggregate(ctx, r.client.GetMaxRates, titles, quote, period)
+}
+
+func (r *remote) GetMinRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error) {
+	return r.aggregate(ctx, r.client.GetMinRates, titles, quote, period)
+}
+
+func (r *remote) GetAvgRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error) {
+	return r.aggregate(ctx, r.client.GetAvgRates, titles, quote, period)
+}
+
+func (r *remote) ActualizeRates(ctx context.Context) error {
+	_, err := r.client.ActualizeRates(ctx, &ratesv1.ActualizeRatesRequest{})
+
+	return err
+}
+
+func (r *remote) aggregate(
+	ctx context.Context,
+	call func(context.Context, *ratesv1.GetAggregateRatesRequest, ...grpc.CallOption) (*ratesv1.GetAggregateRatesResponse, error),
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	req := &ratesv1.GetAggregateRatesRequest{Titles: titles, Quote: quote}
+
+	if !period.From.IsZero() {
+		req.From = timestamppb.New(period.From)
+	}
+
+	if !period.To.IsZero() {
+		req.To = timestamppb.New(period.To)
+	}
+
+	resp, err := call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCoins(resp.GetRates())
+}
+
+func newCoins(rates []*ratesv1.Rate) ([]*entities.Coin, error) {
+	coins := make([]*entities.Coin, 0, len(rates))
+
+	for _, rate := range rates {
+		cost, err := decimal.NewFromString(rate.GetCost())
+		if err != nil {
+			return nil, fmt.Errorf("invalid cost %q of %s: %w", rate.GetCost(), rate.GetTitle(), err)
+		}
+
+		coins = append(coins, &entities.Coin{
+			Title:    rate.GetTitle(),
+			Quote:    rate.GetQuote(),
+			Cost:     cost,
+			ActualAt: rate.GetActualAt().AsTime(),
+		})
+	}
+
+	return coins, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	ratesv1 "crypto-project/api/rates/v1"
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/grpcapi"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestRunRates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	storage := memory.NewStorage()
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: now.Add(-2 * time.Hour)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(120), ActualAt: now.Add(-30 * time.Minute)},
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(10), ActualAt: now.Add(-10 * time.Minute)},
+	}))
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), storage)
+	require.NoError(t, err)
+
+	clients := map[string]ratesClient{
+		"direct": service,
+		"remote": newRemote(t, service),
+	}
+
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			require.NoError(t, runRates(ctx, &out, client, options{}, "last", []string{"BTC,ETH"}))
+			require.Contains(t, out.String(), "TITLE")
+			require.Regexp(t, `BTC\s+USD\s+120\s`, out.String())
+			require.Regexp(t, `ETH\s+USD\s+10\s`, out.String())
+
+			out.Reset()
+
+			require.NoError(t, runRates(ctx, &out, client, options{json: true}, "min", []string{"-window", "1h", "BTC"}))
+			require.JSONEq(t,
+				`{"title":"BTC","quote":"USD","cost":"120","actual_at":"`+now.Add(-30*time.Minute).Format(time.RFC3339)+`"}`,
+				out.String())
+
+			err := runRates(ctx, &out, client, options{}, "max", []string{"-window", "1h", "-from", "2024-01-01T00:00:00Z", "BTC"})
+			require.ErrorIs(t, err, entities.ErrInvalidParam)
+		})
+	}
+
+	err = runRates(ctx, &bytes.Buffer{}, newRemote(t, service), options{}, "last", nil)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// newRemote serves service through the gRPC API.
+func newRemote(t *testing.T, service grpcapi.RatesService) *remote {
+	t.Helper()
+
+	server, err := grpcapi.NewServer(service, grpcapi.Config{})
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1 << 20)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &remote{client: ratesv1.NewRatesServiceClient(conn)}
+}
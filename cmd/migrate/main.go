@@ -0,0 +1,42 @@
+// Command migrate manages the schema of the Postgres storage.
+//
+// Usage:
+//
+//	migrate [-dsn DSN] up|down|version
+//
+// The DSN defaults to the POSTGRES_DSN environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"crypto-project/internal/app"
+)
+
+func main() {
+	dsn := flag.String("dsn", os.Getenv("POSTGRES_DSN"), "postgres connection string")
+	steps := flag.Int("steps", 1, "number of migrations to roll back with down")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] up|down|version\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Migrate(ctx, os.Stdout, *dsn, flag.Arg(0), *steps); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,225 @@
+// Command server serves the rates API and keeps the rates history actual.
+// It is configured through the environment, see app.LoadConfig.
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"crypto-project/internal/adapters/metrics"
+	"crypto-project/internal/adapters/transport/grpcapi"
+	"crypto-project/internal/adapters/transport/rest"
+	"crypto-project/internal/adapters/webhook"
+	"crypto-project/internal/app"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/scheduler"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if err := run(ctx, logger); err != nil {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger) error {
+	cfg, err := app.LoadConfig(os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	m := metrics.New()
+
+	storage, closeStorage, err := app.BuildStorage(ctx, cfg, m)
+	if err != nil {
+		return err
+	}
+	defer closeStorage()
+
+	provider, err := app.BuildProvider(cfg, m)
+	if err != nil {
+		return err
+	}
+
+	service, err := cases.NewService(provider, storage)
+	if err != nil {
+		return err
+	}
+
+	service.Alerts, err = cases.NewAlerts(storage, storage)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := cases.NewWebhooks(storage, webhook.NewSender(webhook.Config{Timeout: cfg.WebhookTimeout}), cases.WebhooksConfig{
+		MaxAttempts:    cfg.WebhookMaxAttempts,
+		InitialBackoff: cfg.WebhookInitialBackoff,
+		MaxBackoff:     cfg.WebhookMaxBackoff,
+		Workers:        cfg.WebhookWorkers,
+		OnError: func(err error) {
+			logger.Error("webhook delivery failed", "error", err)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	feed := cases.NewFeed()
+	service.Events = cases.EventPublishers{webhooks, feed}
+
+	instrumented := metrics.NewService(m, service)
+
+	api, err := rest.NewServer(instrumented, rest.Config{
+		RequestTimeout: cfg.RequestTimeout,
+		Alerts:         service.Alerts,
+		Webhooks:       webhooks,
+		Feed:           feed,
+		Heartbeat:      cfg.StreamHeartbeat,
+		Metrics:        m.Handler(),
+	})
+	if err != nil {
+		return err
+	}
+
+	actualizer, err := scheduler.New(instrumented.ActualizeRates, scheduler.Config{
+		Interval:   cfg.ActualizeInterval,
+		Jitter:     cfg.ActualizeJitter,
+		RunTimeout: cfg.ActualizeTimeout,
+		OnRunFinished: func(status scheduler.Status, err error) {
+			if err != nil {
+				logger.Error("failed to actualize rates", "error", err, "duration", status.LastDuration)
+				return
+			}
+
+			logger.Info("rates actualized", "duration", status.LastDuration, "skipped", status.Skipped)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Cancelling ctx stops the actualization loop and the webhook deliveries.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	httpServer := &http.Server{
+		Addr:              cfg.HTTPAddr,
+		Handler:           api,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+
+	// Shutdown does not wait for the hijacked stream connections, ending the
+	// feed closes them.
+	httpServer.RegisterOnShutdown(feed.Close)
+
+	grpcServer, err := grpcapi.NewServer(instrumented, grpcapi.Config{
+		RequestTimeout: cfg.RequestTimeout,
+		Feed:           feed,
+		Actualize:      actualizer.RunNow,
+	})
+	if err != nil {
+		return err
+	}
+
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		actualizer.Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		webhooks.Run(ctx)
+	}()
+
+	serveErrs := make(chan error, 2)
+
+	go func() {
+		logger.Info("serving http", "addr", cfg.HTTPAddr)
+
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErrs <- err
+			return
+		}
+
+		serveErrs <- nil
+	}()
+
+	go func() {
+		logger.Info("serving grpc", "addr", grpcListener.Addr())
+		serveErrs <- grpcServer.Serve(grpcListener)
+	}()
+
+	pending := 2
+
+	select {
+	case err = <-serveErrs:
+		// One server failed, stop the other one too.
+		pending--
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down")
+	cancel()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+
+	// Shutdown stops accepting connections and waits for in-flight requests.
+	err = errors.Join(err, httpServer.Shutdown(shutdownCtx), stopGRPC(shutdownCtx, grpcServer))
+
+	for range pending {
+		err = errors.Join(err, <-serveErrs)
+	}
+
+	wg.Wait()
+
+	return err
+}
+
+// stopGRPC waits for the in-flight calls like http.Server.Shutdown, and cancels
+// them once ctx is done. The WatchRates streams end with the feed.
+func stopGRPC(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		server.GracefulStop()
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+
+		return ctx.Err()
+	}
+}
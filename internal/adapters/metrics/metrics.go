@@ -0,0 +1,207 @@
+// Package metrics instruments the service, the storage and the providers with
+// Prometheus metrics:
+//
+//	crypto_service_calls_total{method}
+//	crypto_service_errors_total{method, code}
+//	crypto_service_call_duration_seconds{method}
+//	crypto_storage_calls_total{operation}, and so on
+//	crypto_provider_calls_total{provider}, and so on
+//	crypto_provider_served_rates_total{served_by}
+//	crypto_actualize_rates_total
+//	crypto_actualize_last_run_rates
+//	crypto_newest_rate_age_seconds
+//
+// Error codes are the entities.Code of the errors.
+package metrics
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"crypto-project/internal/entities"
+)
+
+const namespace = "crypto"
+
+// servedByNone labels the titles no provider of a fallback chain served.
+const servedByNone = "none"
+
+// Metrics keeps the metrics in a registry of its own, together with the
+// runtime metrics of the process.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	service  calls
+	storage  calls
+	provider calls
+
+	servedRates        *prometheus.CounterVec
+	actualizedRates    prometheus.Counter
+	lastRunActualRates prometheus.Gauge
+	// newestRate is the actual time of the newest stored rate, in unix
+	// nanoseconds, or zero until a rate is stored.
+	newestRate atomic.Int64
+	startedAt  time.Time
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry:  prometheus.NewRegistry(),
+		startedAt: time.Now(),
+		service:   newCalls("service", "method"),
+		storage:   newCalls("storage", "operation"),
+		provider:  newCalls("provider", "provider"),
+		servedRates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "provider",
+			Name:      "served_rates_total",
+			Help:      "Titles a fallback chain served, by the provider that served them.",
+		}, []string{"served_by"}),
+		actualizedRates: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "actualize",
+			Name:      "rates_total",
+			Help:      "Rates stored by the actualizations.",
+		}),
+		lastRunActualRates: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "actualize",
+			Name:      "last_run_rates",
+			Help:      "Rates stored by the latest actualization.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.servedRates,
+		m.actualizedRates,
+		m.lastRunActualRates,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "newest_rate_age_seconds",
+			Help:      "Age of the newest stored rate.",
+		}, m.newestRateAge),
+	)
+
+	for _, c := range []calls{m.service, m.storage, m.provider} {
+		m.registry.MustRegister(c.calls, c.errors, c.duration)
+	}
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveActualized records the rates an actualization stored, see
+// cases.Service.OnActualized.
+func (m *Metrics) ObserveActualized(stored int) {
+	m.actualizedRates.Add(float64(stored))
+	m.lastRunActualRates.Set(float64(stored))
+}
+
+// ObserveServed records which provider served every title of a fallback chain
+// call, served mapping titles to provider names, and the titles missing from
+// it, see fallback.Provider.OnReport.
+func (m *Metrics) ObserveServed(served map[string]string, missing []string) {
+	for _, name := range served {
+		m.servedRates.WithLabelValues(name).Inc()
+	}
+
+	m.servedRates.WithLabelValues(servedByNone).Add(float64(len(missing)))
+}
+
+// observeStored keeps the actual time of the newest rate of coins.
+func (m *Metrics) observeStored(coins []*entities.Coin) {
+	for _, coin := range coins {
+		at := coin.ActualAt.UnixNano()
+
+		for {
+			newest := m.newestRate.Load()
+			if at <= newest || m.newestRate.CompareAndSwap(newest, at) {
+				break
+			}
+		}
+	}
+}
+
+// newestRateAge counts from the start of the process until a rate is stored,
+// so that it grows also when actualization never succeeds.
+func (m *Metrics) newestRateAge() float64 {
+	newest := m.newestRate.Load()
+	if newest == 0 {
+		return time.Since(m.startedAt).Seconds()
+	}
+
+	return time.Since(time.Unix(0, newest)).Seconds()
+}
+
+// calls counts and times the calls of one layer, labelled by label.
+type calls struct {
+	calls    *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newCalls(subsystem, label string) calls {
+	return calls{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "calls_total",
+			Help:      "Calls of the " + subsystem + ".",
+		}, []string{label}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "errors_total",
+			Help:      "Failed calls of the " + subsystem + " by error code.",
+		}, []string{label, "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "call_duration_seconds",
+			Help:      "Duration of the calls of the " + subsystem + ".",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{label}),
+	}
+}
+
+// observe records a call to name that started at start and failed with err,
+// if not nil.
+func (c calls) observe(name string, start time.Time, err error) {
+	c.calls.WithLabelValues(name).Inc()
+	c.duration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		c.errors.WithLabelValues(name, string(entities.CodeOf(err))).Inc()
+	}
+}
+
+// observe calls call and records it to c under name.
+func observe[T any](c calls, name string, call func() (T, error)) (T, error) {
+	start := time.Now()
+
+	result, err := call()
+	c.observe(name, start, err)
+
+	return result, err
+}
+
+// observeErr is observe for calls returning only an error.
+func observeErr(c calls, name string, call func() error) error {
+	start := time.Now()
+
+	err := call()
+	c.observe(name, start, err)
+
+	return err
+}
@@ -0,0 +1,152 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/metrics"
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+// scrape returns the metrics m serves in the text exposition format.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+// value returns the value of the sample of metrics matching series, such as
+// `crypto_service_calls_total{method="GetLastRates"}`.
+func value(t *testing.T, metrics, series string) float64 {
+	t.Helper()
+
+	match := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(series) + ` (\S+)$`).FindStringSubmatch(metrics)
+	require.NotNil(t, match, "no %s in\n%s", series, metrics)
+
+	v, err := strconv.ParseFloat(match[1], 64)
+	require.NoError(t, err)
+
+	return v
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := metrics.New()
+
+	ctrl := gomock.NewController(t)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+
+	actualAt := time.Now().Add(-time.Hour)
+
+	mockProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+		Return([]*entities.Coin{{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: actualAt}}, nil).
+		Times(2)
+	mockProvider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"NOPE"}, "USD").
+		Return(nil, entities.NewProviderError("coingecko", []string{"NOPE"}, true, io.ErrUnexpectedEOF))
+
+	storage := metrics.NewStorage(m, memory.NewStorage())
+
+	service, err := cases.NewService(metrics.NewProvider(m, "coingecko", mockProvider), storage)
+	require.NoError(t, err)
+
+	instrumented := metrics.NewService(m, service)
+
+	_, err = instrumented.GetLastRates(ctx, []string{"BTC"}, "USD")
+	require.NoError(t, err)
+
+	_, err = instrumented.GetLastRates(ctx, []string{"NOPE"}, "USD")
+	require.ErrorIs(t, err, entities.ErrProvider)
+
+	_, err = instrumented.GetLastRates(ctx, nil, "USD")
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	require.NoError(t, instrumented.ActualizeRates(ctx))
+
+	scraped := scrape(t, m)
+
+	require.Equal(t, 3.0, value(t, scraped, `crypto_service_calls_total{method="GetLastRates"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_service_errors_total{code="invalid_param",method="GetLastRates"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_service_errors_total{code="provider_failure",method="GetLastRates"}`))
+	require.Equal(t, 3.0, value(t, scraped, `crypto_service_call_duration_seconds_count{method="GetLastRates"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_service_calls_total{method="ActualizeRates"}`))
+
+	require.Equal(t, 3.0, value(t, scraped, `crypto_provider_calls_total{provider="coingecko"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_provider_errors_total{code="provider_failure",provider="coingecko"}`))
+
+	require.Equal(t, 2.0, value(t, scraped, `crypto_storage_calls_total{operation="Store"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_storage_calls_total{operation="GetActualCoin"}`))
+
+	require.Equal(t, 1.0, value(t, scraped, `crypto_actualize_rates_total`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_actualize_last_run_rates`))
+
+	age := value(t, scraped, `crypto_newest_rate_age_seconds`)
+	require.InDelta(t, time.Hour.Seconds(), age, 60)
+
+	// Go runtime metrics are served as well.
+	require.Contains(t, scraped, "go_goroutines")
+}
+
+func TestNewestRateAge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := metrics.New()
+	storage := metrics.NewStorage(m, memory.NewStorage())
+
+	// Until a rate is stored the age counts from the start.
+	require.Less(t, value(t, scrape(t, m), `crypto_newest_rate_age_seconds`), 60.0)
+
+	now := time.Now()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(1), ActualAt: now.Add(-2 * time.Hour)},
+	}))
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(1), ActualAt: now.Add(-3 * time.Hour)},
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(1), ActualAt: now.Add(-time.Minute)},
+	}))
+
+	// Older rates, such as a backfill, do not make it older.
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "SOL", Quote: "USD", Cost: decimal.NewFromInt(1), ActualAt: now.Add(-24 * time.Hour)},
+	}))
+
+	require.InDelta(t, time.Minute.Seconds(), value(t, scrape(t, m), `crypto_newest_rate_age_seconds`), 10)
+}
+
+func TestObserveServed(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+
+	m.ObserveServed(map[string]string{"BTC": "coingecko", "ETH": "binance", "SOL": "coingecko"}, []string{"NOPE"})
+	m.ObserveServed(map[string]string{"BTC": "coingecko"}, nil)
+
+	scraped := scrape(t, m)
+
+	require.Equal(t, 3.0, value(t, scraped, `crypto_provider_served_rates_total{served_by="coingecko"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_provider_served_rates_total{served_by="binance"}`))
+	require.Equal(t, 1.0, value(t, scraped, `crypto_provider_served_rates_total{served_by="none"}`))
+}
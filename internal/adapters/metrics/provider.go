@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// Provider instruments a provider under its name.
+type Provider struct {
+	next    cases.CryptoProvider
+	name    string
+	metrics *Metrics
+}
+
+var _ cases.CryptoProvider = (*Provider)(nil)
+
+func NewProvider(m *Metrics, name string, provider cases.CryptoProvider) *Provider {
+	return &Provider{next: provider, name: name, metrics: m}
+}
+
+func (p *Provider) GetActualRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	return observe(p.metrics.provider, p.name, func() ([]*entities.Coin, error) {
+		return p.next.GetActualRates(ctx, titles, quote)
+	})
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// Service instruments every use case of a cases.Service the transports and
+// the scheduler call.
+type Service struct {
+	next    *cases.Service
+	metrics *Metrics
+}
+
+// NewService instruments service and records the rates its actualizations
+// store through service.OnActualized.
+func NewService(m *Metrics, service *cases.Service) *Service {
+	service.OnActualized = m.ObserveActualized
+
+	return &Service{next: service, metrics: m}
+}
+
+func (s *Service) GetLastRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	return observe(s.metrics.service, "GetLastRates", func() ([]*entities.Coin, error) {
+		return s.next.GetLastRates(ctx, titles, quote)
+	})
+}
+
+func (s *Service) GetRatesAfter(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	after entities.RateCursor,
+	limit int,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.service, "GetRatesAfter", func() ([]*entities.Coin, error) {
+		return s.next.GetRatesAfter(ctx, titles, quote, after, limit)
+	})
+}
+
+func (s *Service) GetMaxRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.service, "GetMaxRates", func() ([]*entities.Coin, error) {
+		return s.next.GetMaxRates(ctx, titles, quote, period)
+	})
+}
+
+func (s *Service) GetMinRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.service, "GetMinRates", func() ([]*entities.Coin, error) {
+		return s.next.GetMinRates(ctx, titles, quote, period)
+	})
+}
+
+func (s *Service) GetAvgRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.service, "GetAvgRates", func() ([]*entities.Coin, error) {
+		return s.next.GetAvgRates(ctx, titles, quote, period)
+	})
+}
+
+func (s *Service) GetCandles(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	interval entities.Interval,
+	period entities.Period,
+) ([]*entities.Candle, error) {
+	return observe(s.metrics.service, "GetCandles", func() ([]*entities.Candle, error) {
+		return s.next.GetCandles(ctx, titles, quote, interval, period)
+	})
+}
+
+func (s *Service) Convert(
+	ctx context.Context,
+	from, to string,
+	amount decimal.Decimal,
+	maxAge time.Duration,
+) (*entities.Conversion, error) {
+	return observe(s.metrics.service, "Convert", func() (*entities.Conversion, error) {
+		return s.next.Convert(ctx, from, to, amount, maxAge)
+	})
+}
+
+func (s *Service) ValuePortfolio(
+	ctx context.Context,
+	holdings []entities.Holding,
+	quote string,
+) (*entities.Valuation, error) {
+	return observe(s.metrics.service, "ValuePortfolio", func() (*entities.Valuation, error) {
+		return s.next.ValuePortfolio(ctx, holdings, quote)
+	})
+}
+
+func (s *Service) ActualizeRates(ctx context.Context) error {
+	return observeErr(s.metrics.service, "ActualizeRates", func() error {
+		return s.next.ActualizeRates(ctx)
+	})
+}
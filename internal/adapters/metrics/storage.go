@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// Backend is every storage port the server uses, as app.Storage.
+type Backend interface {
+	cases.Storage
+	cases.AlertStorage
+	cases.WebhookStorage
+}
+
+// Storage instruments every operation of a storage, and keeps the age of the
+// newest rate stored through it.
+type Storage struct {
+	next    Backend
+	metrics *Metrics
+}
+
+var _ Backend = (*Storage)(nil)
+
+func NewStorage(m *Metrics, storage Backend) *Storage {
+	return &Storage{next: storage, metrics: m}
+}
+
+func (s *Storage) Store(ctx context.Context, coins []*entities.Coin) error {
+	err := observeErr(s.metrics.storage, "Store", func() error {
+		return s.next.Store(ctx, coins)
+	})
+	if err == nil {
+		s.metrics.observeStored(coins)
+	}
+
+	return err
+}
+
+func (s *Storage) GetQuotesList(ctx context.Context) ([]string, error) {
+	return observe(s.metrics.storage, "GetQuotesList", func() ([]string, error) {
+		return s.next.GetQuotesList(ctx)
+	})
+}
+
+func (s *Storage) GetCoinsList(ctx context.Context, quote string) ([]string, error) {
+	return observe(s.metrics.storage, "GetCoinsList", func() ([]string, error) {
+		return s.next.GetCoinsList(ctx, quote)
+	})
+}
+
+func (s *Storage) GetActualCoin(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	return observe(s.metrics.storage, "GetActualCoin", func() ([]*entities.Coin, error) {
+		return s.next.GetActualCoin(ctx, titles, quote)
+	})
+}
+
+func (s *Storage) GetHistoricalCoin(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	at time.Time,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.storage, "GetHistoricalCoin", func() ([]*entities.Coin, error) {
+		return s.next.GetHistoricalCoin(ctx, titles, quote, at)
+	})
+}
+
+func (s *Storage) GetRatesAfter(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	after entities.RateCursor,
+	limit int,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.storage, "GetRatesAfter", func() ([]*entities.Coin, error) {
+		return s.next.GetRatesAfter(ctx, titles, quote, after, limit)
+	})
+}
+
+func (s *Storage) GetAggregateCoins(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	aggType string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return observe(s.metrics.storage, "GetAggregateCoins", func() ([]*entities.Coin, error) {
+		return s.next.GetAggregateCoins(ctx, titles, quote, aggType, period)
+	})
+}
+
+func (s *Storage) GetCandles(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	interval entities.Interval,
+	period entities.Period,
+) ([]*entities.Candle, error) {
+	return observe(s.metrics.storage, "GetCandles", func() ([]*entities.Candle, error) {
+		return s.next.GetCandles(ctx, titles, quote, interval, period)
+	})
+}
+
+func (s *Storage) CreateAlertRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error) {
+	return observe(s.metrics.storage, "CreateAlertRule", func() (*entities.AlertRule, error) {
+		return s.next.CreateAlertRule(ctx, rule)
+	})
+}
+
+func (s *Storage) GetAlertRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	return observe(s.metrics.storage, "GetAlertRules", func() ([]*entities.AlertRule, error) {
+		return s.next.GetAlertRules(ctx)
+	})
+}
+
+func (s *Storage) DeleteAlertRule(ctx context.Context, id int64) error {
+	return observeErr(s.metrics.storage, "DeleteAlertRule", func() error {
+		return s.next.DeleteAlertRule(ctx, id)
+	})
+}
+
+func (s *Storage) SaveAlertEvaluation(
+	ctx context.Context,
+	rules []*entities.AlertRule,
+	events []*entities.AlertEvent,
+) error {
+	return observeErr(s.metrics.storage, "SaveAlertEvaluation", func() error {
+		return s.next.SaveAlertEvaluation(ctx, rules, events)
+	})
+}
+
+func (s *Storage) GetAlertEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error) {
+	return observe(s.metrics.storage, "GetAlertEvents", func() ([]*entities.AlertEvent, error) {
+		return s.next.GetAlertEvents(ctx, ruleID, limit)
+	})
+}
+
+func (s *Storage) CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error) {
+	return observe(s.metrics.storage, "CreateWebhook", func() (*entities.Webhook, error) {
+		return s.next.CreateWebhook(ctx, webhook)
+	})
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	return observe(s.metrics.storage, "GetWebhooks", func() ([]*entities.Webhook, error) {
+		return s.next.GetWebhooks(ctx)
+	})
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id int64) error {
+	return observeErr(s.metrics.storage, "DeleteWebhook", func() error {
+		return s.next.DeleteWebhook(ctx, id)
+	})
+}
+
+func (s *Storage) SaveDeadLetter(ctx context.Context, letter *entities.DeadLetter) error {
+	return observeErr(s.metrics.storage, "SaveDeadLetter", func() error {
+		return s.next.SaveDeadLetter(ctx, letter)
+	})
+}
+
+func (s *Storage) GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	return observe(s.metrics.storage, "GetDeadLetters", func() ([]*entities.DeadLetter, error) {
+		return s.next.GetDeadLetters(ctx, limit)
+	})
+}
+
+func (s *Storage) GetDeadLetter(ctx context.Context, id int64) (*entities.DeadLetter, error) {
+	return observe(s.metrics.storage, "GetDeadLetter", func() (*entities.DeadLetter, error) {
+		return s.next.GetDeadLetter(ctx, id)
+	})
+}
+
+func (s *Storage) DeleteDeadLetter(ctx context.Context, id int64) error {
+	return observeErr(s.metrics.storage, "DeleteDeadLetter", func() error {
+		return s.next.DeleteDeadLetter(ctx, id)
+	})
+}
@@ -0,0 +1,297 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultBaseURL    = "https://api.binance.com"
+	DefaultTimeout    = 10 * time.Second
+	DefaultQuoteAsset = "USDT"
+
+	sourceName = "binance"
+
+	// codeInvalidSymbol is returned for the whole request when any of the
+	// requested symbols is not listed.
+	codeInvalidSymbol = -1121
+)
+
+type Config struct {
+	BaseURL string
+	Timeout time.Duration
+	// QuoteAsset is the asset USD rates are read in: it is appended to the base
+	// asset to build a symbol, e.g. BTC + USDT. Other quotes are read from
+	// their own markets, e.g. BTC + EUR.
+	QuoteAsset string
+	// QuoteUSDSymbol is the symbol pricing QuoteAsset in USD, e.g. USDTUSD on
+	// Binance.US. When empty QuoteAsset is treated as pegged 1:1 to USD.
+	QuoteUSDSymbol string
+	// BaseAssets maps titles to exchange base assets, e.g. "Bitcoin" -> "BTC".
+	// Titles missing from the map are used uppercased.
+	BaseAssets map[string]string
+	HTTPClient *http.Client
+}
+
+// Provider reads exchange ticker prices from a Binance-compatible
+// /api/v3/ticker/price endpoint.
+type Provider struct {
+	baseURL        string
+	timeout        time.Duration
+	quoteAsset     string
+	quoteUSDSymbol string
+	baseAssets     map[string]string
+	client         *http.Client
+}
+
+var _ cases.CryptoProvider = (*Provider)(nil)
+
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+
+	if _, err := url.ParseRequestURI(cfg.BaseURL); err != nil {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid base url: %v", err)
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	if cfg.QuoteAsset == "" {
+		cfg.QuoteAsset = DefaultQuoteAsset
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{}
+	}
+
+	return &Provider{
+		baseURL:        strings.TrimRight(cfg.BaseURL, "/"),
+		timeout:        cfg.Timeout,
+		quoteAsset:     strings.ToUpper(cfg.QuoteAsset),
+		quoteUSDSymbol: strings.ToUpper(cfg.QuoteUSDSymbol),
+		baseAssets:     cfg.BaseAssets,
+		client:         cfg.HTTPClient,
+	}, nil
+}
+
+type tickerPrice struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// apiError is a non-200 response, with the Binance error body if there was one.
+type apiError struct {
+	status int
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+var errMalformedResponse = errors.New("malformed response")
+
+// GetActualRates returns the rates of titles in quote. USD rates are read in
+// QuoteAsset and converted with QuoteUSDSymbol. Titles without a listed
+// symbol are omitted from the result.
+func (p *Provider) GetActualRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	if len(titles) == 0 {
+		return []*entities.Coin{}, nil
+	}
+
+	titlesBySymbol := make(map[string][]string, len(titles))
+	symbols := make([]string, 0, len(titles)+1)
+	seen := make(map[string]struct{}, len(titles))
+
+	for _, title := range titles {
+		if _, ok := seen[title]; ok {
+			continue
+		}
+
+		seen[title] = struct{}{}
+
+		symbol := p.symbol(title, quote)
+		if _, ok := titlesBySymbol[symbol]; !ok {
+			symbols = append(symbols, symbol)
+		}
+
+		titlesBySymbol[symbol] = append(titlesBySymbol[symbol], title)
+	}
+
+	convertUSD := quote == entities.DefaultQuote && p.quoteUSDSymbol != ""
+
+	if convertUSD {
+		if _, ok := titlesBySymbol[p.quoteUSDSymbol]; !ok {
+			symbols = append(symbols, p.quoteUSDSymbol)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	prices, err := p.fetchPrices(ctx, symbols)
+	if err != nil {
+		return nil, entities.NewProviderError(sourceName, titles, isRetryable(err), err)
+	}
+
+	quoteUSD := decimal.NewFromInt(1)
+
+	if convertUSD {
+		rate, ok := prices[p.quoteUSDSymbol]
+		if !ok || !rate.IsPositive() {
+			return nil, entities.NewProviderError(sourceName, titles, false,
+				errors.Errorf("no %s rate to convert %s to USD", p.quoteUSDSymbol, p.quoteAsset))
+		}
+
+		quoteUSD = rate
+	}
+
+	now := time.Now()
+	coins := make([]*entities.Coin, 0, len(titles))
+
+	for _, symbol := range symbols {
+		price, ok := prices[symbol]
+		if !ok {
+			continue
+		}
+
+		for _, title := range titlesBySymbol[symbol] {
+			coin, err := entities.NewCoin(title, quote, price.Mul(quoteUSD), now)
+			if err != nil {
+				continue
+			}
+
+			coins = append(coins, coin)
+		}
+	}
+
+	return coins, nil
+}
+
+// fetchPrices requests the given symbols. Since one unlisted symbol fails the
+// whole request, it falls back to the full ticker list in that case.
+func (p *Provider) fetchPrices(ctx context.Context, symbols []string) (map[string]decimal.Decimal, error) {
+	encoded, err := json.Marshal(symbols)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode symbols")
+	}
+
+	tickers, err := p.getTickers(ctx, url.Values{"symbols": {string(encoded)}})
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.Code == codeInvalidSymbol {
+		tickers, err = p.getTickers(ctx, nil)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+
+	wanted := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		wanted[symbol] = struct{}{}
+	}
+
+	prices := make(map[string]decimal.Decimal, len(symbols))
+
+	for _, ticker := range tickers {
+		if _, ok := wanted[ticker.Symbol]; !ok {
+			continue
+		}
+
+		price, err := decimal.NewFromString(ticker.Price)
+		if err != nil {
+			return nil, errors.Wrapf(errMalformedResponse, "price %q of %s", ticker.Price, ticker.Symbol)
+		}
+
+		prices[ticker.Symbol] = price
+	}
+
+	return prices, nil
+}
+
+func (p *Provider) getTickers(ctx context.Context, query url.Values) ([]tickerPrice, error) {
+	endpoint := p.baseURL + "/api/v3/ticker/price"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		apiErr := &apiError{status: resp.StatusCode}
+		if json.Unmarshal(body, apiErr) != nil || apiErr.Code == 0 {
+			apiErr.Msg = strings.TrimSpace(string(body))
+		}
+
+		return nil, apiErr
+	}
+
+	var tickers []tickerPrice
+	if err = json.NewDecoder(resp.Body).Decode(&tickers); err != nil {
+		return nil, errors.Wrapf(errMalformedResponse, "failed to decode: %v", err)
+	}
+
+	return tickers, nil
+}
+
+func (p *Provider) symbol(title, quote string) string {
+	base, ok := p.baseAssets[title]
+	if !ok {
+		base = title
+	}
+
+	if quote == entities.DefaultQuote {
+		quote = p.quoteAsset
+	}
+
+	return strings.ToUpper(base) + strings.ToUpper(quote)
+}
+
+func (e *apiError) Error() string {
+	if e.Code == 0 {
+		return "status " + strconv.Itoa(e.status) + ": " + e.Msg
+	}
+
+	return "status " + strconv.Itoa(e.status) + ", code " + strconv.Itoa(e.Code) + ": " + e.Msg
+}
+
+// isRetryable treats transport failures, rate limits and server errors as
+// transient.
+func isRetryable(err error) bool {
+	if errors.Is(err, errMalformedResponse) {
+		return false
+	}
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.status == http.StatusTooManyRequests || apiErr.status >= http.StatusInternalServerError
+	}
+
+	return true
+}
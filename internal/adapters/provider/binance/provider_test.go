@@ -0,0 +1,209 @@
+package binance_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/adapters/provider/binance"
+	"crypto-project/internal/entities"
+)
+
+// exchangeStub mimics /api/v3/ticker/price: a request for any unlisted symbol
+// fails with code -1121, a request without symbols returns every ticker.
+func exchangeStub(t *testing.T, listed map[string]string, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Path != "/api/v3/ticker/price" {
+			http.NotFound(w, r)
+			return
+		}
+
+		type ticker struct {
+			Symbol string `json:"symbol"`
+			Price  string `json:"price"`
+		}
+
+		tickers := make([]ticker, 0, len(listed))
+
+		if raw := r.URL.Query().Get("symbols"); raw != "" {
+			var symbols []string
+			require.NoError(t, json.Unmarshal([]byte(raw), &symbols))
+
+			for _, symbol := range symbols {
+				price, ok := listed[symbol]
+				if !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+					return
+				}
+
+				tickers = append(tickers, ticker{Symbol: symbol, Price: price})
+			}
+		} else {
+			for symbol, price := range listed {
+				tickers = append(tickers, ticker{Symbol: symbol, Price: price})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(tickers))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+var listed = map[string]string{
+	"BTCUSDT": "67187.33000000",
+	"ETHUSDT": "3456.78000000",
+	"TONUSDT": "5.12000000",
+	"USDTUSD": "0.99900000",
+	"BTCEUR":  "61020.00000000",
+	"ETHBTC":  "0.05140000",
+}
+
+func TestGetActualRates(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := exchangeStub(t, listed, &requests)
+
+	provider, err := binance.NewProvider(binance.Config{
+		BaseURL:    server.URL,
+		BaseAssets: map[string]string{"Bitcoin": "btc"},
+	})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), []string{"Bitcoin", "eth", "BTC"}, "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, requests.Load())
+
+	require.Len(t, coins, 3)
+	require.Equal(t, "Bitcoin", coins[0].Title)
+	require.Equal(t, "67187.33", coins[0].Cost.String())
+	require.Equal(t, "BTC", coins[1].Title)
+	require.Equal(t, "67187.33", coins[1].Cost.String())
+	require.Equal(t, "eth", coins[2].Title)
+	require.Equal(t, "3456.78", coins[2].Cost.String())
+}
+
+func TestGetActualRatesUnlistedSymbol(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := exchangeStub(t, listed, &requests)
+
+	provider, err := binance.NewProvider(binance.Config{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), []string{"TON", "NOTLISTED"}, "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, requests.Load())
+
+	require.Len(t, coins, 1)
+	require.Equal(t, "TON", coins[0].Title)
+	require.Equal(t, "5.12", coins[0].Cost.String())
+}
+
+func TestGetActualRatesQuoteConversion(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := exchangeStub(t, listed, &requests)
+
+	provider, err := binance.NewProvider(binance.Config{BaseURL: server.URL, QuoteUSDSymbol: "USDTUSD"})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), []string{"TON"}, "USD")
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, "5.11488", coins[0].Cost.String())
+
+	provider, err = binance.NewProvider(binance.Config{BaseURL: server.URL, QuoteUSDSymbol: "NOPEUSD"})
+	require.NoError(t, err)
+
+	_, err = provider.GetActualRates(context.Background(), []string{"TON"}, "USD")
+	require.ErrorIs(t, err, entities.ErrProvider)
+}
+
+func TestGetActualRatesOtherQuotes(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := exchangeStub(t, listed, &requests)
+
+	// QuoteUSDSymbol only applies to USD rates.
+	provider, err := binance.NewProvider(binance.Config{BaseURL: server.URL, QuoteUSDSymbol: "NOPEUSD"})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), []string{"BTC", "TON"}, "EUR")
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, "BTC", coins[0].Title)
+	require.Equal(t, "EUR", coins[0].Quote)
+	require.Equal(t, "61020", coins[0].Cost.String())
+
+	coins, err = provider.GetActualRates(context.Background(), []string{"ETH"}, "BTC")
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, "BTC", coins[0].Quote)
+	require.Equal(t, "0.0514", coins[0].Cost.String())
+}
+
+func TestGetActualRatesErrors(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "banned",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+				_, _ = w.Write([]byte(`{"code":-1003,"msg":"Way too many requests; IP banned."}`))
+			},
+		},
+		{
+			name: "malformed price",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","price":"n/a"}]`))
+			},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tc.handler)
+			t.Cleanup(server.Close)
+
+			provider, err := binance.NewProvider(binance.Config{BaseURL: server.URL})
+			require.NoError(t, err)
+
+			coins, err := provider.GetActualRates(context.Background(), []string{"BTC"}, "USD")
+			require.ErrorIs(t, err, entities.ErrProvider)
+			require.Nil(t, coins)
+		})
+	}
+}
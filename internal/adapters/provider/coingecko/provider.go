@@ -0,0 +1,213 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultBaseURL = "https://api.coingecko.com/api/v3"
+	DefaultTimeout = 10 * time.Second
+
+	// HeaderDemoAPIKey authenticates demo plan keys on the public API,
+	// HeaderProAPIKey authenticates paid plan keys on pro-api.coingecko.com.
+	HeaderDemoAPIKey = "x-cg-demo-api-key"
+	HeaderProAPIKey  = "x-cg-pro-api-key"
+
+	sourceName = "coingecko"
+)
+
+// DefaultIDs maps common tickers to CoinGecko coin IDs. Titles missing from the
+// map are looked up by their lowercased form, e.g. "Bitcoin" -> "bitcoin".
+var DefaultIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"ETC":  "ethereum-classic",
+	"TON":  "the-open-network",
+	"SOL":  "solana",
+	"USDT": "tether",
+	"BNB":  "binancecoin",
+	"XRP":  "ripple",
+	"DOGE": "dogecoin",
+	"ADA":  "cardano",
+}
+
+type Config struct {
+	BaseURL string
+	APIKey  string
+	// APIKeyHeader defaults to HeaderDemoAPIKey.
+	APIKeyHeader string
+	Timeout      time.Duration
+	// IDs overrides DefaultIDs.
+	IDs        map[string]string
+	HTTPClient *http.Client
+}
+
+// Provider fetches rates from the CoinGecko /simple/price endpoint.
+type Provider struct {
+	baseURL      string
+	apiKey       string
+	apiKeyHeader string
+	timeout      time.Duration
+	ids          map[string]string
+	client       *http.Client
+}
+
+var _ cases.CryptoProvider = (*Provider)(nil)
+
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+
+	if _, err := url.ParseRequestURI(cfg.BaseURL); err != nil {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid base url: %v", err)
+	}
+
+	if cfg.APIKeyHeader == "" {
+		cfg.APIKeyHeader = HeaderDemoAPIKey
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	if cfg.IDs == nil {
+		cfg.IDs = DefaultIDs
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{}
+	}
+
+	return &Provider{
+		baseURL:      strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:       cfg.APIKey,
+		apiKeyHeader: cfg.APIKeyHeader,
+		timeout:      cfg.Timeout,
+		ids:          cfg.IDs,
+		client:       cfg.HTTPClient,
+	}, nil
+}
+
+// simplePriceResponse maps coin IDs to their prices keyed by the lowercased
+// quote currency, next to a last_updated_at unix timestamp.
+type simplePriceResponse map[string]map[string]json.RawMessage
+
+// GetActualRates returns the rates of titles in quote, which CoinGecko calls
+// the vs currency. Titles CoinGecko does not know or has no valid price for
+// are omitted from the result.
+func (p *Provider) GetActualRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	if len(titles) == 0 {
+		return []*entities.Coin{}, nil
+	}
+
+	titlesByID := make(map[string][]string, len(titles))
+	ids := make([]string, 0, len(titles))
+	seen := make(map[string]struct{}, len(titles))
+
+	for _, title := range titles {
+		if _, ok := seen[title]; ok {
+			continue
+		}
+
+		seen[title] = struct{}{}
+
+		id := p.coinID(title)
+		if _, ok := titlesByID[id]; !ok {
+			ids = append(ids, id)
+		}
+
+		titlesByID[id] = append(titlesByID[id], title)
+	}
+
+	query := url.Values{}
+	query.Set("ids", strings.Join(ids, ","))
+	query.Set("vs_currencies", strings.ToLower(quote))
+	query.Set("include_last_updated_at", "true")
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/simple/price?"+query.Encode(), nil)
+	if err != nil {
+		return nil, entities.NewProviderError(sourceName, titles, false, errors.Wrap(err, "failed to build request"))
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if p.apiKey != "" {
+		req.Header.Set(p.apiKeyHeader, p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, entities.NewProviderError(sourceName, titles, true, errors.Wrap(err, "request failed"))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+		return nil, entities.NewProviderError(sourceName, titles, retryable,
+			errors.Errorf("responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(body))))
+	}
+
+	var prices simplePriceResponse
+	if err = json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+		return nil, entities.NewProviderError(sourceName, titles, false, errors.Wrap(err, "failed to decode response"))
+	}
+
+	now := time.Now()
+	coins := make([]*entities.Coin, 0, len(titles))
+
+	for _, id := range ids {
+		fields, ok := prices[id]
+		if !ok {
+			continue
+		}
+
+		price, err := decimal.NewFromString(string(fields[strings.ToLower(quote)]))
+		if err != nil {
+			continue
+		}
+
+		actualAt := now
+
+		var lastUpdatedAt int64
+		if err = json.Unmarshal(fields["last_updated_at"], &lastUpdatedAt); err == nil && lastUpdatedAt > 0 {
+			actualAt = time.Unix(lastUpdatedAt, 0)
+		}
+
+		for _, title := range titlesByID[id] {
+			coin, err := entities.NewCoin(title, quote, price, actualAt)
+			if err != nil {
+				continue
+			}
+
+			coins = append(coins, coin)
+		}
+	}
+
+	return coins, nil
+}
+
+func (p *Provider) coinID(title string) string {
+	if id, ok := p.ids[title]; ok {
+		return id
+	}
+
+	return strings.ToLower(title)
+}
@@ -0,0 +1,175 @@
+package coingecko_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/adapters/provider/coingecko"
+	"crypto-project/internal/entities"
+)
+
+// fixtureServer replies to /simple/price with the recorded fixture file and
+// the given status, capturing the last request.
+func fixtureServer(t *testing.T, status int, fixture string, lastReq **http.Request) *httptest.Server {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lastReq != nil {
+			*lastReq = r
+		}
+
+		if r.URL.Path != "/simple/price" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGetActualRates(t *testing.T) {
+	t.Parallel()
+
+	var req *http.Request
+
+	server := fixtureServer(t, http.StatusOK, "simple_price.json", &req)
+
+	provider, err := coingecko.NewProvider(coingecko.Config{
+		BaseURL:      server.URL,
+		APIKey:       "secret",
+		APIKeyHeader: coingecko.HeaderProAPIKey,
+	})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), []string{"BTC", "Ethereum", "TON", "USDT", "UNKNOWN", "BTC"}, "USD")
+	require.NoError(t, err)
+
+	require.Equal(t, "secret", req.Header.Get(coingecko.HeaderProAPIKey))
+	require.Equal(t, "bitcoin,ethereum,the-open-network,tether,unknown", req.URL.Query().Get("ids"))
+	require.Equal(t, "usd", req.URL.Query().Get("vs_currencies"))
+
+	require.Len(t, coins, 3)
+	require.Equal(t, &entities.Coin{Title: "BTC", Quote: "USD", Cost: decimal.RequireFromString("67187.33"), ActualAt: time.Unix(1711356300, 0)}, coins[0])
+	require.Equal(t, &entities.Coin{Title: "Ethereum", Quote: "USD", Cost: decimal.RequireFromString("3456.78"), ActualAt: time.Unix(1711356285, 0)}, coins[1])
+	require.Equal(t, "TON", coins[2].Title)
+	require.Equal(t, "5.12", coins[2].Cost.String())
+	require.WithinDuration(t, time.Now(), coins[2].ActualAt, time.Minute)
+}
+
+func TestGetActualRatesQuote(t *testing.T) {
+	t.Parallel()
+
+	var req *http.Request
+
+	server := fixtureServer(t, http.StatusOK, "simple_price_eur.json", &req)
+
+	provider, err := coingecko.NewProvider(coingecko.Config{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), []string{"BTC", "ETH"}, "EUR")
+	require.NoError(t, err)
+
+	require.Equal(t, "eur", req.URL.Query().Get("vs_currencies"))
+	require.Equal(t, []*entities.Coin{
+		{Title: "BTC", Quote: "EUR", Cost: decimal.RequireFromString("61874.0123456789"), ActualAt: time.Unix(1711356300, 0)},
+	}, coins)
+}
+
+func TestGetActualRatesEmptyTitles(t *testing.T) {
+	t.Parallel()
+
+	provider, err := coingecko.NewProvider(coingecko.Config{BaseURL: "http://127.0.0.1:0"})
+	require.NoError(t, err)
+
+	coins, err := provider.GetActualRates(context.Background(), nil, "USD")
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+func TestGetActualRatesErrors(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name    string
+		status  int
+		fixture string
+	}{
+		{
+			name:    "rate limited",
+			status:  http.StatusTooManyRequests,
+			fixture: "rate_limited.json",
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			fixture: "rate_limited.json",
+		},
+		{
+			name:    "malformed body",
+			status:  http.StatusOK,
+			fixture: "malformed.json",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := fixtureServer(t, tc.status, tc.fixture, nil)
+
+			provider, err := coingecko.NewProvider(coingecko.Config{BaseURL: server.URL})
+			require.NoError(t, err)
+
+			coins, err := provider.GetActualRates(context.Background(), []string{"BTC"}, "USD")
+			require.ErrorIs(t, err, entities.ErrProvider)
+			require.Nil(t, coins)
+		})
+	}
+}
+
+func TestGetActualRatesTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	provider, err := coingecko.NewProvider(coingecko.Config{BaseURL: server.URL, Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = provider.GetActualRates(context.Background(), []string{"BTC"}, "USD")
+	require.ErrorIs(t, err, entities.ErrProvider)
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Parallel()
+
+	_, err := coingecko.NewProvider(coingecko.Config{BaseURL: "not a url"})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = coingecko.NewProvider(coingecko.Config{})
+	require.NoError(t, err)
+}
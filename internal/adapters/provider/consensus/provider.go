@@ -0,0 +1,240 @@
+package consensus
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/adapters/provider/named"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultMaxDeviation = 0.02
+
+	sourceName = "consensus"
+)
+
+type Config struct {
+	Providers []named.Provider
+	// MaxDeviation is the largest accepted relative distance from the median,
+	// e.g. 0.02 accepts quotes within ±2%. Defaults to DefaultMaxDeviation.
+	MaxDeviation float64
+	// Quorum is the number of accepted quotes a title needs. Defaults to a
+	// majority of Providers.
+	Quorum int
+}
+
+// Report describes how a GetActualRates call reached consensus.
+type Report struct {
+	// Accepted maps every returned title to the providers whose quotes were used.
+	Accepted map[string][]string
+	// Rejected maps titles to the providers whose quotes fell out of the band.
+	Rejected map[string][]string
+	// Failed holds the errors of the providers that failed.
+	Failed named.Failures
+	// Missing lists the titles that did not reach the quorum.
+	Missing []string
+}
+
+// Provider queries all its providers concurrently and returns, per title, the
+// median of the quotes that agree within MaxDeviation of each other.
+type Provider struct {
+	providers    []named.Provider
+	maxDeviation decimal.Decimal
+	quorum       int
+}
+
+var _ cases.CryptoProvider = (*Provider)(nil)
+
+func NewProvider(cfg Config) (*Provider, error) {
+	if err := named.Validate(cfg.Providers); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxDeviation == 0 {
+		cfg.MaxDeviation = DefaultMaxDeviation
+	}
+
+	if cfg.MaxDeviation < 0 || math.IsNaN(cfg.MaxDeviation) || math.IsInf(cfg.MaxDeviation, 0) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "max deviation must be positive")
+	}
+
+	if cfg.Quorum == 0 {
+		cfg.Quorum = len(cfg.Providers)/2 + 1
+	}
+
+	if cfg.Quorum < 0 || cfg.Quorum > len(cfg.Providers) {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "quorum must be between 1 and %d", len(cfg.Providers))
+	}
+
+	return &Provider{
+		providers:    cfg.Providers,
+		maxDeviation: decimal.NewFromFloat(cfg.MaxDeviation),
+		quorum:       cfg.Quorum,
+	}, nil
+}
+
+func (p *Provider) GetActualRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	coins, _, err := p.GetActualRatesWithReport(ctx, titles, quote)
+
+	return coins, err
+}
+
+type vote struct {
+	provider string
+	coin     *entities.Coin
+}
+
+// GetActualRatesWithReport returns the consensus rates of titles. Titles that
+// do not reach the quorum are omitted. It fails when too few providers
+// answered for any title to reach the quorum.
+func (p *Provider) GetActualRatesWithReport(
+	ctx context.Context,
+	titles []string,
+	quote string,
+) ([]*entities.Coin, Report, error) {
+	report := Report{
+		Accepted: make(map[string][]string, len(titles)),
+		Rejected: make(map[string][]string),
+		Failed:   make(named.Failures),
+	}
+
+	results := make([][]*entities.Coin, len(p.providers))
+	errs := make([]error, len(p.providers))
+
+	var wg sync.WaitGroup
+
+	for i, provider := range p.providers {
+		wg.Add(1)
+
+		go func(i int, provider cases.CryptoProvider) {
+			defer wg.Done()
+
+			results[i], errs[i] = provider.GetActualRates(ctx, titles, quote)
+		}(i, provider.Provider)
+	}
+
+	wg.Wait()
+
+	quotes := make(map[string][]vote, len(titles))
+
+	for i, provider := range p.providers {
+		if errs[i] != nil {
+			report.Failed[provider.Name] = errs[i]
+			continue
+		}
+
+		seen := make(map[string]struct{}, len(results[i]))
+
+		for _, coin := range results[i] {
+			if coin == nil || !coin.Cost.IsPositive() {
+				continue
+			}
+
+			// A provider votes once per title.
+			if _, ok := seen[coin.Title]; ok {
+				continue
+			}
+
+			seen[coin.Title] = struct{}{}
+			quotes[coin.Title] = append(quotes[coin.Title], vote{provider: provider.Name, coin: coin})
+		}
+	}
+
+	if answered := len(p.providers) - len(report.Failed); answered < p.quorum {
+		return nil, report, entities.NewProviderError(sourceName, titles, report.Failed.Retryable(), errors.Errorf(
+			"only %d of %d providers answered, quorum is %d: %s", answered, len(p.providers), p.quorum, report.Failed))
+	}
+
+	coins := make([]*entities.Coin, 0, len(titles))
+	done := make(map[string]struct{}, len(titles))
+
+	for _, title := range titles {
+		if _, ok := done[title]; ok {
+			continue
+		}
+
+		done[title] = struct{}{}
+
+		coin, accepted, rejected := p.agree(title, quotes[title])
+
+		if len(rejected) > 0 {
+			report.Rejected[title] = rejected
+		}
+
+		if coin == nil {
+			report.Missing = append(report.Missing, title)
+			continue
+		}
+
+		report.Accepted[title] = accepted
+		coins = append(coins, coin)
+	}
+
+	return coins, report, nil
+}
+
+// agree drops the quotes deviating from the median by more than maxDeviation
+// and returns the median of the rest if they reach the quorum.
+func (p *Provider) agree(title string, quotes []vote) (*entities.Coin, []string, []string) {
+	if len(quotes) < p.quorum {
+		return nil, nil, nil
+	}
+
+	costs := make([]decimal.Decimal, 0, len(quotes))
+	for _, q := range quotes {
+		costs = append(costs, q.coin.Cost)
+	}
+
+	mid := median(costs)
+
+	accepted := make([]string, 0, len(quotes))
+	rejected := make([]string, 0)
+	acceptedCosts := make([]decimal.Decimal, 0, len(quotes))
+	var actualAt time.Time
+
+	for _, q := range quotes {
+		if q.coin.Cost.Sub(mid).Abs().GreaterThan(mid.Mul(p.maxDeviation)) {
+			rejected = append(rejected, q.provider)
+			continue
+		}
+
+		accepted = append(accepted, q.provider)
+		acceptedCosts = append(acceptedCosts, q.coin.Cost)
+
+		if q.coin.ActualAt.After(actualAt) {
+			actualAt = q.coin.ActualAt
+		}
+	}
+
+	if len(accepted) < p.quorum {
+		return nil, nil, rejected
+	}
+
+	return &entities.Coin{
+		Title:    title,
+		Quote:    quotes[0].coin.Quote,
+		Cost:     median(acceptedCosts),
+		ActualAt: actualAt,
+	}, accepted, rejected
+}
+
+func median(values []decimal.Decimal) decimal.Decimal {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, decimal.Decimal.Cmp)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		// Halving by multiplying with 0.5 keeps the result exact.
+		return sorted[mid-1].Add(sorted[mid]).Mul(decimal.New(5, -1))
+	}
+
+	return sorted[mid]
+}
@@ -0,0 +1,177 @@
+package consensus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/provider/consensus"
+	"crypto-project/internal/adapters/provider/named"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestGetActualRatesWithReport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	later := now.Add(time.Second)
+
+	coin := func(title string, cost string, actualAt time.Time) *entities.Coin {
+		return &entities.Coin{Title: title, Quote: "USD", Cost: decimal.RequireFromString(cost), ActualAt: actualAt}
+	}
+
+	testTable := []struct {
+		name             string
+		quorum           int
+		responses        [3][]*entities.Coin
+		failures         [3]error
+		expectedCoins    []*entities.Coin
+		expectedRejected map[string][]string
+		expectedMissing  []string
+		expectedErr      error
+	}{
+		{
+			name: "median of agreeing quotes",
+			responses: [3][]*entities.Coin{
+				{coin("BTC", "100", now), coin("ETH", "10", now)},
+				{coin("BTC", "101", later), coin("ETH", "10.1", now)},
+				{coin("BTC", "99.5", now), coin("ETH", "9.9", now)},
+			},
+			expectedCoins:    []*entities.Coin{coin("BTC", "100", later), coin("ETH", "10", now)},
+			expectedRejected: map[string][]string{},
+		},
+		{
+			name: "outlier is rejected",
+			responses: [3][]*entities.Coin{
+				{coin("BTC", "100", now)},
+				{coin("BTC", "1000", later)},
+				{coin("BTC", "101", now)},
+			},
+			expectedCoins:    []*entities.Coin{coin("BTC", "100.5", now)},
+			expectedRejected: map[string][]string{"BTC": {"b"}},
+		},
+		{
+			name: "title without quorum is missing",
+			responses: [3][]*entities.Coin{
+				{coin("BTC", "100", now), coin("TON", "5", now)},
+				{coin("BTC", "100", now)},
+				{coin("BTC", "100", now), coin("TON", "50", now)},
+			},
+			expectedCoins:    []*entities.Coin{coin("BTC", "100", now)},
+			expectedRejected: map[string][]string{"TON": {"a", "c"}},
+			expectedMissing:  []string{"TON"},
+		},
+		{
+			name: "failed provider below quorum still agrees",
+			responses: [3][]*entities.Coin{
+				{coin("BTC", "100", now)},
+				nil,
+				{coin("BTC", "100", now)},
+			},
+			failures:         [3]error{nil, entities.ErrProvider, nil},
+			expectedCoins:    []*entities.Coin{coin("BTC", "100", now)},
+			expectedRejected: map[string][]string{},
+		},
+		{
+			name: "not enough providers answered",
+			responses: [3][]*entities.Coin{
+				{coin("BTC", "100", now)},
+			},
+			failures:    [3]error{nil, entities.ErrProvider, entities.ErrProvider},
+			expectedErr: entities.ErrProvider,
+		},
+		{
+			name:   "explicit quorum of one",
+			quorum: 1,
+			responses: [3][]*entities.Coin{
+				{coin("TON", "5", now)},
+			},
+			failures:         [3]error{nil, entities.ErrProvider, entities.ErrProvider},
+			expectedCoins:    []*entities.Coin{coin("TON", "5", now)},
+			expectedRejected: map[string][]string{},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			titles := []string{"BTC", "ETH", "TON"}
+			names := []string{"a", "b", "c"}
+			providers := make([]named.Provider, 0, len(names))
+
+			for i, name := range names {
+				mock := mocks.NewMockCryptoProvider(ctrl)
+				mock.EXPECT().
+					GetActualRates(gomock.Any(), titles, "USD").
+					Return(tc.responses[i], tc.failures[i])
+
+				providers = append(providers, named.Provider{Name: name, Provider: mock})
+			}
+
+			provider, err := consensus.NewProvider(consensus.Config{Providers: providers, Quorum: tc.quorum})
+			require.NoError(t, err)
+
+			coins, report, err := provider.GetActualRatesWithReport(context.Background(), titles, "USD")
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, coins)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, coins, len(tc.expectedCoins))
+
+			for i, expected := range tc.expectedCoins {
+				require.Equal(t, expected.Title, coins[i].Title)
+				require.Equal(t, expected.Quote, coins[i].Quote)
+				require.True(t, expected.Cost.Equal(coins[i].Cost), "%s: expected %s, got %s", expected.Title, expected.Cost, coins[i].Cost)
+				require.Equal(t, expected.ActualAt, coins[i].ActualAt)
+			}
+			require.Equal(t, tc.expectedRejected, report.Rejected)
+
+			for _, title := range tc.expectedMissing {
+				require.Contains(t, report.Missing, title)
+			}
+
+			for _, coin := range coins {
+				require.GreaterOrEqual(t, len(report.Accepted[coin.Title]), 1)
+			}
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mock := mocks.NewMockCryptoProvider(ctrl)
+
+	providers := []named.Provider{{Name: "a", Provider: mock}, {Name: "b", Provider: mock}}
+
+	testTable := []struct {
+		name string
+		cfg  consensus.Config
+	}{
+		{name: "no providers", cfg: consensus.Config{}},
+		{name: "nil provider", cfg: consensus.Config{Providers: []named.Provider{{Name: "a"}}}},
+		{name: "duplicate names", cfg: consensus.Config{Providers: []named.Provider{providers[0], providers[0]}}},
+		{name: "negative deviation", cfg: consensus.Config{Providers: providers, MaxDeviation: -1}},
+		{name: "quorum above providers", cfg: consensus.Config{Providers: providers, Quorum: 3}},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := consensus.NewProvider(tc.cfg)
+			require.ErrorIs(t, err, entities.ErrInvalidParam)
+		})
+	}
+}
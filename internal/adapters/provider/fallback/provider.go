@@ -0,0 +1,136 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/adapters/provider/named"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const sourceName = "fallback"
+
+// Report describes how a GetActualRates call was served.
+type Report struct {
+	// Served maps every returned title to the name of the provider that served it.
+	Served map[string]string
+	// Failed holds the errors of the providers that failed.
+	Failed named.Failures
+	// Missing lists the titles no provider served.
+	Missing []string
+}
+
+// Provider asks its providers in order and, per title, falls back to the next
+// provider when the previous one failed or omitted the title.
+type Provider struct {
+	// OnReport, if set, is called with the report of every GetActualRates.
+	OnReport func(Report)
+
+	providers []named.Provider
+}
+
+var _ cases.CryptoProvider = (*Provider)(nil)
+
+func NewProvider(providers ...named.Provider) (*Provider, error) {
+	if err := named.Validate(providers); err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		providers: providers,
+	}, nil
+}
+
+func (p *Provider) GetActualRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	coins, report, err := p.GetActualRatesWithReport(ctx, titles, quote)
+
+	if p.OnReport != nil {
+		p.OnReport(report)
+	}
+
+	return coins, err
+}
+
+// GetActualRatesWithReport returns the rates of titles along with which
+// provider served each of them. Titles no provider served while one of them
+// failed may be held by that provider: they fail as unavailable, along with
+// the rates that were served, rather than being reported unknown.
+func (p *Provider) GetActualRatesWithReport(
+	ctx context.Context,
+	titles []string,
+	quote string,
+) ([]*entities.Coin, Report, error) {
+	report := Report{
+		Served: make(map[string]string, len(titles)),
+		Failed: make(named.Failures),
+	}
+
+	remaining := make([]string, 0, len(titles))
+	pending := make(map[string]struct{}, len(titles))
+
+	for _, title := range titles {
+		if _, ok := pending[title]; !ok {
+			pending[title] = struct{}{}
+			remaining = append(remaining, title)
+		}
+	}
+
+	coins := make([]*entities.Coin, 0, len(remaining))
+
+	for _, provider := range p.providers {
+		if len(remaining) == 0 {
+			break
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, report, entities.NewProviderError(sourceName, remaining, false, err)
+		}
+
+		served, err := provider.Provider.GetActualRates(ctx, remaining, quote)
+		if err != nil {
+			report.Failed[provider.Name] = err
+			continue
+		}
+
+		for _, coin := range served {
+			if coin == nil {
+				continue
+			}
+
+			if _, ok := pending[coin.Title]; !ok {
+				continue
+			}
+
+			delete(pending, coin.Title)
+			report.Served[coin.Title] = provider.Name
+			coins = append(coins, coin)
+		}
+
+		next := make([]string, 0, len(pending))
+
+		for _, title := range remaining {
+			if _, ok := pending[title]; ok {
+				next = append(next, title)
+			}
+		}
+
+		remaining = next
+	}
+
+	report.Missing = remaining
+
+	if len(remaining) > 0 && len(report.Failed) > 0 {
+		err := entities.NewProviderError(sourceName, remaining, report.Failed.Retryable(),
+			errors.New("providers failed: "+report.Failed.String()))
+
+		if len(coins) == 0 {
+			return nil, report, err
+		}
+
+		return coins, report, err
+	}
+
+	return coins, report, nil
+}
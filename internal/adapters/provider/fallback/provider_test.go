@@ -0,0 +1,206 @@
+package fallback_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/provider/fallback"
+	"crypto-project/internal/adapters/provider/named"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestGetActualRatesWithReport(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name           string
+		titles         []string
+		setupMock      func(primary, secondary *mocks.MockCryptoProvider)
+		expectedCoins  []*entities.Coin
+		expectedServed map[string]string
+		expectedFailed []string
+		expectedMiss   []string
+		expectedErr    error
+	}{
+		{
+			name:   "primary serves everything",
+			titles: []string{"BTC", "ETH"},
+			setupMock: func(primary, secondary *mocks.MockCryptoProvider) {
+				primary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC", "ETH"}, "USD").
+					Return([]*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}, {Title: "ETH", Cost: decimal.NewFromInt(2)}}, nil)
+			},
+			expectedCoins:  []*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}, {Title: "ETH", Cost: decimal.NewFromInt(2)}},
+			expectedServed: map[string]string{"BTC": "primary", "ETH": "primary"},
+			expectedMiss:   []string{},
+		},
+		{
+			name:   "secondary serves omitted titles",
+			titles: []string{"BTC", "ETH", "TON", "BTC"},
+			setupMock: func(primary, secondary *mocks.MockCryptoProvider) {
+				primary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC", "ETH", "TON"}, "USD").
+					Return([]*entities.Coin{{Title: "ETH", Cost: decimal.NewFromInt(2)}, {Title: "DOGE", Cost: decimal.NewFromInt(9)}}, nil)
+				secondary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC", "TON"}, "USD").
+					Return([]*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}, {Title: "ETH", Cost: decimal.NewFromInt(3)}}, nil)
+			},
+			expectedCoins:  []*entities.Coin{{Title: "ETH", Cost: decimal.NewFromInt(2)}, {Title: "BTC", Cost: decimal.NewFromInt(1)}},
+			expectedServed: map[string]string{"BTC": "secondary", "ETH": "primary"},
+			expectedMiss:   []string{"TON"},
+		},
+		{
+			name:   "secondary serves when primary fails",
+			titles: []string{"BTC"},
+			setupMock: func(primary, secondary *mocks.MockCryptoProvider) {
+				primary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+					Return(nil, entities.ErrProvider)
+				secondary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+					Return([]*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}}, nil)
+			},
+			expectedCoins:  []*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}},
+			expectedServed: map[string]string{"BTC": "secondary"},
+			expectedFailed: []string{"primary"},
+			expectedMiss:   []string{},
+		},
+		{
+			name:   "all providers fail",
+			titles: []string{"BTC"},
+			setupMock: func(primary, secondary *mocks.MockCryptoProvider) {
+				primary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+					Return(nil, entities.ErrProvider)
+				secondary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+					Return(nil, entities.ErrStorage)
+			},
+			expectedServed: map[string]string{},
+			expectedFailed: []string{"primary", "secondary"},
+			expectedMiss:   []string{"BTC"},
+			expectedErr:    entities.ErrProvider,
+		},
+		{
+			name:   "failed provider may hold the missing titles",
+			titles: []string{"BTC", "TON"},
+			setupMock: func(primary, secondary *mocks.MockCryptoProvider) {
+				primary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC", "TON"}, "USD").
+					Return(nil, entities.ErrProvider)
+				secondary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"BTC", "TON"}, "USD").
+					Return([]*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}}, nil)
+			},
+			expectedCoins:  []*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}},
+			expectedServed: map[string]string{"BTC": "secondary"},
+			expectedFailed: []string{"primary"},
+			expectedMiss:   []string{"TON"},
+			expectedErr:    entities.ErrProvider,
+		},
+		{
+			name:   "nobody knows the title",
+			titles: []string{"NOPE"},
+			setupMock: func(primary, secondary *mocks.MockCryptoProvider) {
+				primary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"NOPE"}, "USD").
+					Return([]*entities.Coin{}, nil)
+				secondary.EXPECT().
+					GetActualRates(gomock.Any(), []string{"NOPE"}, "USD").
+					Return(nil, nil)
+			},
+			expectedCoins:  []*entities.Coin{},
+			expectedServed: map[string]string{},
+			expectedMiss:   []string{"NOPE"},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			primary := mocks.NewMockCryptoProvider(ctrl)
+			secondary := mocks.NewMockCryptoProvider(ctrl)
+			tc.setupMock(primary, secondary)
+
+			provider, err := fallback.NewProvider(
+				named.Provider{Name: "primary", Provider: primary},
+				named.Provider{Name: "secondary", Provider: secondary},
+			)
+			require.NoError(t, err)
+
+			coins, report, err := provider.GetActualRatesWithReport(context.Background(), tc.titles, "USD")
+
+			require.Equal(t, tc.expectedServed, report.Served)
+			require.Equal(t, tc.expectedMiss, report.Missing)
+			require.Len(t, report.Failed, len(tc.expectedFailed))
+
+			for _, name := range tc.expectedFailed {
+				require.Contains(t, report.Failed, name)
+			}
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Equal(t, tc.expectedMiss, entities.TitlesOf(err))
+				require.Equal(t, tc.expectedCoins, coins)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCoins, coins)
+		})
+	}
+}
+
+func TestOnReport(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	primary := mocks.NewMockCryptoProvider(ctrl)
+	primary.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC", "ETH"}, "USD").
+		Return([]*entities.Coin{{Title: "BTC", Cost: decimal.NewFromInt(1)}}, nil)
+
+	provider, err := fallback.NewProvider(named.Provider{Name: "primary", Provider: primary})
+	require.NoError(t, err)
+
+	var reports []fallback.Report
+
+	provider.OnReport = func(report fallback.Report) {
+		reports = append(reports, report)
+	}
+
+	_, err = provider.GetActualRates(context.Background(), []string{"BTC", "ETH"}, "USD")
+	require.NoError(t, err)
+
+	require.Len(t, reports, 1)
+	require.Equal(t, map[string]string{"BTC": "primary"}, reports[0].Served)
+	require.Equal(t, []string{"ETH"}, reports[0].Missing)
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockCryptoProvider(ctrl)
+
+	_, err := fallback.NewProvider()
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = fallback.NewProvider(named.Provider{Name: "nil"})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = fallback.NewProvider(
+		named.Provider{Name: "dup", Provider: provider},
+		named.Provider{Name: "dup", Provider: provider},
+	)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,75 @@
+// Package named holds what the providers combining several others share.
+package named
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// Provider is a provider under the name reports refer to it by.
+type Provider struct {
+	Name     string
+	Provider cases.CryptoProvider
+}
+
+// Validate checks that there are providers, every one set under its own name.
+func Validate(providers []Provider) error {
+	if len(providers) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "providers not set")
+	}
+
+	names := make(map[string]struct{}, len(providers))
+
+	for _, p := range providers {
+		if p.Provider == nil {
+			return errors.Wrapf(entities.ErrInvalidParam, "provider %q not set", p.Name)
+		}
+
+		if _, ok := names[p.Name]; ok {
+			return errors.Wrapf(entities.ErrInvalidParam, "duplicate provider name %q", p.Name)
+		}
+
+		names[p.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// Failures maps provider names to the errors they returned.
+type Failures map[string]error
+
+// Retryable reports whether any of the failures is transient.
+func (f Failures) Retryable() bool {
+	for _, err := range f {
+		if entities.IsRetryable(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String lists the failures ordered by provider name, such as
+// "binance: timeout; coingecko: rate limited".
+func (f Failures) String() string {
+	names := make([]string, 0, len(f))
+
+	for name := range f {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+
+	for _, name := range names {
+		parts = append(parts, name+": "+f[name].Error())
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,47 @@
+package named_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/provider/named"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	provider := mocks.NewMockCryptoProvider(gomock.NewController(t))
+
+	require.NoError(t, named.Validate([]named.Provider{{Name: "a", Provider: provider}, {Name: "b", Provider: provider}}))
+
+	for _, providers := range [][]named.Provider{
+		nil,
+		{{Name: "nil"}},
+		{{Name: "dup", Provider: provider}, {Name: "dup", Provider: provider}},
+	} {
+		require.ErrorIs(t, named.Validate(providers), entities.ErrInvalidParam)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	t.Parallel()
+
+	failures := named.Failures{
+		"coingecko": errors.New("rate limited"),
+		"binance":   entities.NewProviderError("binance", nil, false, errors.New("bad symbol")),
+	}
+
+	require.Equal(t, "binance: "+failures["binance"].Error()+"; coingecko: rate limited", failures.String())
+	require.False(t, failures.Retryable())
+
+	failures["kraken"] = entities.NewProviderError("kraken", nil, true, errors.New("timeout"))
+	require.True(t, failures.Retryable())
+
+	require.False(t, named.Failures{}.Retryable())
+	require.Empty(t, named.Failures{}.String())
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+func (s *Storage) CreateAlertRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastRuleID++
+	rule.ID = s.lastRuleID
+	s.alertRules[rule.ID] = rule
+
+	return &rule, nil
+}
+
+func (s *Storage) GetAlertRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rules := make([]*entities.AlertRule, 0, len(s.alertRules))
+
+	for _, rule := range s.alertRules {
+		rules = append(rules, &rule)
+	}
+
+	slices.SortFunc(rules, func(a, b *entities.AlertRule) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return rules, nil
+}
+
+func (s *Storage) DeleteAlertRule(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.alertRules[id]; !ok {
+		return errors.Wrapf(entities.ErrNotFound, "alert rule %d", id)
+	}
+
+	delete(s.alertRules, id)
+
+	s.alertEvents = slices.DeleteFunc(s.alertEvents, func(event entities.AlertEvent) bool {
+		return event.RuleID == id
+	})
+
+	return nil
+}
+
+func (s *Storage) SaveAlertEvaluation(
+	ctx context.Context,
+	rules []*entities.AlertRule,
+	events []*entities.AlertEvent,
+) error {
+	if err := ctx.Err(); err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rule := range rules {
+		stored, ok := s.alertRules[rule.ID]
+		if !ok {
+			continue
+		}
+
+		stored.Firing, stored.FiredAt = rule.Firing, rule.FiredAt
+		s.alertRules[rule.ID] = stored
+	}
+
+	for _, event := range events {
+		if _, ok := s.alertRules[event.RuleID]; !ok {
+			continue
+		}
+
+		s.lastEventID++
+		event.ID = s.lastEventID
+		s.alertEvents = append(s.alertEvents, *event)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetAlertEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]*entities.AlertEvent, 0)
+
+	for i := len(s.alertEvents) - 1; i >= 0 && len(events) < limit; i-- {
+		if event := s.alertEvents[i]; ruleID == 0 || event.RuleID == ruleID {
+			events = append(events, &event)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,340 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const sourceName = "memory"
+
+// Storage keeps the full history of coin rates in memory. It mirrors the
+// semantics of the postgres adapter and is safe for concurrent use.
+type Storage struct {
+	mu sync.RWMutex
+	// history of every pair ordered by ActualAt, equal times in insertion order.
+	history map[pair][]entities.Coin
+	lastSeq int64
+
+	alertRules  map[int64]entities.AlertRule
+	alertEvents []entities.AlertEvent
+	lastRuleID  int64
+	lastEventID int64
+
+	webhooks         map[int64]entities.Webhook
+	deadLetters      map[int64]entities.DeadLetter
+	lastWebhookID    int64
+	lastDeadLetterID int64
+}
+
+// pair keys the history: rates in different quotes are unrelated series.
+type pair struct {
+	title string
+	quote string
+}
+
+var (
+	_ cases.Storage        = (*Storage)(nil)
+	_ cases.AlertStorage   = (*Storage)(nil)
+	_ cases.WebhookStorage = (*Storage)(nil)
+)
+
+func NewStorage() *Storage {
+	return &Storage{
+		history:     make(map[pair][]entities.Coin),
+		alertRules:  make(map[int64]entities.AlertRule),
+		webhooks:    make(map[int64]entities.Webhook),
+		deadLetters: make(map[int64]entities.DeadLetter),
+	}
+}
+
+func (s *Storage) Store(ctx context.Context, coins []*entities.Coin) error {
+	if err := ctx.Err(); err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, coin := range coins {
+		s.lastSeq++
+		coin.Seq = s.lastSeq
+
+		key := pair{title: coin.Title, quote: coin.Quote}
+		points := s.history[key]
+
+		idx := sort.Search(len(points), func(i int) bool {
+			return points[i].ActualAt.After(coin.ActualAt)
+		})
+
+		points = append(points, entities.Coin{})
+		copy(points[idx+1:], points[idx:])
+		points[idx] = *coin
+
+		s.history[key] = points
+	}
+
+	return nil
+}
+
+func (s *Storage) GetQuotesList(ctx context.Context) ([]string, error) {
+	return s.keys(ctx, func(key pair) (string, bool) {
+		return key.quote, true
+	})
+}
+
+func (s *Storage) GetCoinsList(ctx context.Context, quote string) ([]string, error) {
+	return s.keys(ctx, func(key pair) (string, bool) {
+		return key.title, key.quote == quote
+	})
+}
+
+func (s *Storage) GetActualCoin(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	return s.collect(ctx, titles, quote, func(points []entities.Coin) *entities.Coin {
+		latest := points[len(points)-1]
+		return &latest
+	})
+}
+
+func (s *Storage) GetHistoricalCoin(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	at time.Time,
+) ([]*entities.Coin, error) {
+	return s.collect(ctx, titles, quote, func(points []entities.Coin) *entities.Coin {
+		points = within(points, entities.Period{To: at})
+		if len(points) == 0 {
+			return nil
+		}
+
+		latest := points[len(points)-1]
+		return &latest
+	})
+}
+
+func (s *Storage) GetRatesAfter(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	after entities.RateCursor,
+	limit int,
+) ([]*entities.Coin, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	titles = slices.Clone(titles)
+	slices.Sort(titles)
+	titles = slices.Compact(titles)
+
+	coins := make([]*entities.Coin, 0)
+
+	for _, title := range titles {
+		for _, point := range s.history[pair{title: title, quote: quote}] {
+			if point.Seq > after.Seq {
+				coins = append(coins, &point)
+			}
+		}
+	}
+
+	slices.SortFunc(coins, func(a, b *entities.Coin) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return coins[:min(limit, len(coins))], nil
+}
+
+// GetAggregateCoins folds the history of every title within period with
+// aggType. ActualAt of an aggregated coin is the time of the latest aggregated
+// point, titles without points in period are skipped.
+func (s *Storage) GetAggregateCoins(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	aggType string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	var fold func(acc, cost decimal.Decimal) decimal.Decimal
+
+	switch aggType {
+	case cases.AggTypeMax:
+		fold = func(acc, cost decimal.Decimal) decimal.Decimal { return decimal.Max(acc, cost) }
+	case cases.AggTypeMin:
+		fold = func(acc, cost decimal.Decimal) decimal.Decimal { return decimal.Min(acc, cost) }
+	case cases.AggTypeAvg:
+		fold = func(acc, cost decimal.Decimal) decimal.Decimal { return acc.Add(cost) }
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unknown aggregate type %q", aggType)
+	}
+
+	return s.collect(ctx, titles, quote, func(points []entities.Coin) *entities.Coin {
+		points = within(points, period)
+		if len(points) == 0 {
+			return nil
+		}
+
+		acc := points[0].Cost
+
+		for _, point := range points[1:] {
+			acc = fold(acc, point.Cost)
+		}
+
+		if aggType == cases.AggTypeAvg {
+			acc = acc.DivRound(decimal.NewFromInt(int64(len(points))), entities.PriceScale)
+		}
+
+		return &entities.Coin{
+			Title:    points[0].Title,
+			Quote:    points[0].Quote,
+			Cost:     acc,
+			ActualAt: points[len(points)-1].ActualAt,
+		}
+	})
+}
+
+func (s *Storage) GetCandles(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	interval entities.Interval,
+	period entities.Period,
+) ([]*entities.Candle, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	titles = slices.Clone(titles)
+	slices.Sort(titles)
+	titles = slices.Compact(titles)
+
+	candles := make([]*entities.Candle, 0)
+
+	for _, title := range titles {
+		var candle *entities.Candle
+
+		for _, point := range within(s.history[pair{title: title, quote: quote}], period) {
+			start := interval.Start(point.ActualAt)
+
+			if candle == nil || !candle.Start.Equal(start) {
+				candle = &entities.Candle{
+					Title:    title,
+					Quote:    quote,
+					Interval: interval,
+					Start:    start,
+					Open:     point.Cost,
+					High:     point.Cost,
+					Low:      point.Cost,
+				}
+				candles = append(candles, candle)
+			}
+
+			candle.High = decimal.Max(candle.High, point.Cost)
+			candle.Low = decimal.Min(candle.Low, point.Cost)
+			candle.Close = point.Cost
+		}
+	}
+
+	return candles, nil
+}
+
+// within returns the points of an ordered history that fall into period.
+func within(points []entities.Coin, period entities.Period) []entities.Coin {
+	if !period.From.IsZero() {
+		points = points[sort.Search(len(points), func(i int) bool {
+			return !points[i].ActualAt.Before(period.From)
+		}):]
+	}
+
+	if !period.To.IsZero() {
+		points = points[:sort.Search(len(points), func(i int) bool {
+			return points[i].ActualAt.After(period.To)
+		})]
+	}
+
+	return points
+}
+
+// keys returns the sorted distinct names pick extracts from the stored pairs.
+func (s *Storage) keys(ctx context.Context, pick func(key pair) (string, bool)) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.history))
+
+	for key := range s.history {
+		if name, ok := pick(key); ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names), nil
+}
+
+// collect applies pick to the history in quote of every known title in
+// titles and returns the results ordered by title. Unknown titles and titles
+// pick returns nil for are skipped.
+func (s *Storage) collect(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	pick func(points []entities.Coin) *entities.Coin,
+) ([]*entities.Coin, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]struct{}, len(titles))
+	coins := make([]*entities.Coin, 0, len(titles))
+
+	for _, title := range titles {
+		if _, ok := seen[title]; ok {
+			continue
+		}
+
+		seen[title] = struct{}{}
+
+		points := s.history[pair{title: title, quote: quote}]
+		if len(points) == 0 {
+			continue
+		}
+
+		if coin := pick(points); coin != nil {
+			coins = append(coins, coin)
+		}
+	}
+
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i].Title < coins[j].Title
+	})
+
+	return coins, nil
+}
@@ -0,0 +1,76 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/storage/storagetest"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	storagetest.Run(t, func(t *testing.T) cases.Storage {
+		return memory.NewStorage()
+	})
+}
+
+func TestAlertConformance(t *testing.T) {
+	t.Parallel()
+
+	storagetest.RunAlerts(t, func(t *testing.T) cases.AlertStorage {
+		return memory.NewStorage()
+	})
+}
+
+func TestWebhookConformance(t *testing.T) {
+	t.Parallel()
+
+	storagetest.RunWebhooks(t, func(t *testing.T) cases.WebhookStorage {
+		return memory.NewStorage()
+	})
+}
+
+func TestStorageReturnsCopies(t *testing.T) {
+	t.Parallel()
+
+	storage := memory.NewStorage()
+	ctx := context.Background()
+
+	coin := &entities.Coin{Title: "BTC", Quote: entities.DefaultQuote, Cost: decimal.NewFromInt(100), ActualAt: time.Now()}
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{coin}))
+
+	coin.Cost = decimal.NewFromInt(1)
+
+	actual, err := storage.GetActualCoin(ctx, []string{"BTC"}, entities.DefaultQuote)
+	require.NoError(t, err)
+	require.Equal(t, "100", actual[0].Cost.String())
+
+	actual[0].Cost = decimal.NewFromInt(2)
+
+	actual, err = storage.GetActualCoin(ctx, []string{"BTC"}, entities.DefaultQuote)
+	require.NoError(t, err)
+	require.Equal(t, "100", actual[0].Cost.String())
+}
+
+func TestStorageCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	storage := memory.NewStorage()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: entities.DefaultQuote, Cost: decimal.NewFromInt(1), ActualAt: time.Now()}})
+	require.ErrorIs(t, err, entities.ErrStorage)
+
+	_, err = storage.GetCoinsList(ctx, entities.DefaultQuote)
+	require.ErrorIs(t, err, entities.ErrStorage)
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+func (s *Storage) CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastWebhookID++
+	webhook.ID = s.lastWebhookID
+	webhook.Events = slices.Clone(webhook.Events)
+	s.webhooks[webhook.ID] = webhook
+
+	return &webhook, nil
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]*entities.Webhook, 0, len(s.webhooks))
+
+	for _, webhook := range s.webhooks {
+		webhook.Events = slices.Clone(webhook.Events)
+		webhooks = append(webhooks, &webhook)
+	}
+
+	slices.SortFunc(webhooks, func(a, b *entities.Webhook) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return webhooks, nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return errors.Wrapf(entities.ErrNotFound, "webhook %d", id)
+	}
+
+	delete(s.webhooks, id)
+
+	maps.DeleteFunc(s.deadLetters, func(_ int64, letter entities.DeadLetter) bool {
+		return letter.WebhookID == id
+	})
+
+	return nil
+}
+
+func (s *Storage) SaveDeadLetter(ctx context.Context, letter *entities.DeadLetter) error {
+	if err := ctx.Err(); err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[letter.WebhookID]; !ok {
+		return nil
+	}
+
+	if letter.ID == 0 {
+		s.lastDeadLetterID++
+		letter.ID = s.lastDeadLetterID
+	}
+
+	stored := *letter
+	stored.Delivery.Payload = slices.Clone(letter.Delivery.Payload)
+	s.deadLetters[letter.ID] = stored
+
+	return nil
+}
+
+func (s *Storage) GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]*entities.DeadLetter, 0, len(s.deadLetters))
+
+	for _, letter := range s.deadLetters {
+		letters = append(letters, &letter)
+	}
+
+	slices.SortFunc(letters, func(a, b *entities.DeadLetter) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+
+	return letters[:min(limit, len(letters))], nil
+}
+
+func (s *Storage) GetDeadLetter(ctx context.Context, id int64) (*entities.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letter, ok := s.deadLetters[id]
+	if !ok {
+		return nil, errors.Wrapf(entities.ErrNotFound, "dead letter %d", id)
+	}
+
+	return &letter, nil
+}
+
+func (s *Storage) DeleteDeadLetter(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.deadLetters[id]; !ok {
+		return errors.Wrapf(entities.ErrNotFound, "dead letter %d", id)
+	}
+
+	delete(s.deadLetters, id)
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+func (s *Storage) CreateAlertRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error) {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO alert_rules (title, quote, condition, threshold, "window", cooldown, firing, fired_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		rule.Title, rule.Quote, rule.Condition, rule.Threshold, rule.Window, rule.Cooldown,
+		rule.Firing, nullTime(rule.FiredAt),
+	).Scan(&rule.ID)
+	if err != nil {
+		return nil, storageError(err, "failed to insert alert rule")
+	}
+
+	return &rule, nil
+}
+
+func (s *Storage) GetAlertRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, quote, condition, threshold, "window", cooldown, firing, fired_at
+		FROM alert_rules
+		ORDER BY id`,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select alert rules")
+	}
+
+	rules, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.AlertRule, error) {
+		var (
+			rule    entities.AlertRule
+			firedAt *time.Time
+		)
+
+		if err := row.Scan(
+			&rule.ID, &rule.Title, &rule.Quote, &rule.Condition, &rule.Threshold,
+			&rule.Window, &rule.Cooldown, &rule.Firing, &firedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if firedAt != nil {
+			rule.FiredAt = *firedAt
+		}
+
+		return &rule, nil
+	})
+	if err != nil {
+		return nil, storageError(err, "failed to scan alert rules")
+	}
+
+	return rules, nil
+}
+
+func (s *Storage) DeleteAlertRule(ctx context.Context, id int64) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM alert_rules WHERE id = $1`, id)
+	if err != nil {
+		return storageError(err, "failed to delete alert rule")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrNotFound, "alert rule %d", id)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveAlertEvaluation(
+	ctx context.Context,
+	rules []*entities.AlertRule,
+	events []*entities.AlertEvent,
+) error {
+	if len(rules) == 0 && len(events) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+
+	for _, rule := range rules {
+		batch.Queue(
+			`UPDATE alert_rules SET firing = $2, fired_at = $3 WHERE id = $1`,
+			rule.ID, rule.Firing, nullTime(rule.FiredAt),
+		)
+	}
+
+	for _, event := range events {
+		batch.Queue(`
+			INSERT INTO alert_events (rule_id, title, quote, state, cost, value, at)
+			SELECT id, $2, $3, $4, $5, $6, $7 FROM alert_rules WHERE id = $1
+			RETURNING id`,
+			event.RuleID, event.Title, event.Quote, event.State, event.Cost, event.Value, event.At,
+		).QueryRow(func(row pgx.Row) error {
+			err := row.Scan(&event.ID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+
+			return err
+		})
+	}
+
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+	if err != nil {
+		return storageError(err, "failed to save alert evaluation")
+	}
+
+	return nil
+}
+
+func (s *Storage) GetAlertEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, rule_id, title, quote, state, cost, value, at
+		FROM alert_events
+		WHERE $1::bigint = 0 OR rule_id = $1::bigint
+		ORDER BY id DESC
+		LIMIT $2`,
+		ruleID, limit,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select alert events")
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.AlertEvent, error) {
+		var event entities.AlertEvent
+		if err := row.Scan(
+			&event.ID, &event.RuleID, &event.Title, &event.Quote, &event.State, &event.Cost, &event.Value, &event.At,
+		); err != nil {
+			return nil, err
+		}
+
+		return &event, nil
+	})
+	if err != nil {
+		return nil, storageError(err, "failed to scan alert events")
+	}
+
+	return events, nil
+}
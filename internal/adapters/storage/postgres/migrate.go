@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockID serializes concurrent migrators through pg_advisory_xact_lock.
+const migrationLockID = 7305917431
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// loadMigrations reads the embedded migrations ordered by version. Every
+// version must have both an up and a down file.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list migrations")
+	}
+
+	byVersion := make(map[int]*migration, len(files)/2)
+
+	for _, file := range files {
+		match := migrationFileRe.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, errors.Errorf("malformed migration file name %q", file)
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, errors.Errorf("malformed migration version in %q", file)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration %q", file)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, errors.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, errors.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// MigrateUp applies every embedded migration newer than the current schema
+// version, each one in its own transaction.
+func (s *Storage) MigrateUp(ctx context.Context) error {
+	migrations, err := loadMigrations(migrationsFS)
+	if err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	for _, m := range migrations {
+		err = s.inMigrationTx(ctx, func(tx pgx.Tx, current int) error {
+			if m.Version <= current {
+				return nil
+			}
+
+			if _, err := tx.Exec(ctx, m.Up); err != nil {
+				return err
+			}
+
+			_, err := tx.Exec(ctx, `INSERT INTO schema_version (version) VALUES ($1)`, m.Version)
+
+			return err
+		})
+		if err != nil {
+			return storageError(err, "failed to apply migration %d_%s", m.Version, m.Name)
+		}
+	}
+
+	return nil
+}
+
+// MigrateDown rolls back the latest steps applied migrations.
+func (s *Storage) MigrateDown(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "steps must be positive")
+	}
+
+	migrations, err := loadMigrations(migrationsFS)
+	if err != nil {
+		return entities.NewStorageError(sourceName, false, err)
+	}
+
+	for ; steps > 0; steps-- {
+		done := false
+
+		err = s.inMigrationTx(ctx, func(tx pgx.Tx, current int) error {
+			if current == 0 {
+				done = true
+				return nil
+			}
+
+			idx := sort.Search(len(migrations), func(i int) bool {
+				return migrations[i].Version >= current
+			})
+			if idx == len(migrations) || migrations[idx].Version != current {
+				return errors.Errorf("schema version %d is unknown to this binary", current)
+			}
+
+			if _, err := tx.Exec(ctx, migrations[idx].Down); err != nil {
+				return err
+			}
+
+			_, err := tx.Exec(ctx, `DELETE FROM schema_version WHERE version = $1`, current)
+
+			return err
+		})
+		if err != nil {
+			return storageError(err, "failed to roll back migration")
+		}
+
+		if done {
+			break
+		}
+	}
+
+	return nil
+}
+
+// SchemaVersion returns the latest applied migration version, 0 for an empty database.
+func (s *Storage) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+
+	err := s.inMigrationTx(ctx, func(_ pgx.Tx, current int) error {
+		version = current
+		return nil
+	})
+	if err != nil {
+		return 0, storageError(err, "failed to get schema version")
+	}
+
+	return version, nil
+}
+
+func (s *Storage) inMigrationTx(ctx context.Context, fn func(tx pgx.Tx, current int) error) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, `
+			CREATE TABLE IF NOT EXISTS schema_version (
+				version    INTEGER PRIMARY KEY,
+				applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			)`)
+		if err != nil {
+			return err
+		}
+
+		var current int
+		if err = tx.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&current); err != nil {
+			return err
+		}
+
+		return fn(tx, current)
+	})
+}
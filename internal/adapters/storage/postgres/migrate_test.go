@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		fsys     fstest.MapFS
+		expected []migration
+		wantErr  bool
+	}{
+		{
+			name: "ordered by version",
+			fsys: fstest.MapFS{
+				"migrations/0002_add_index.up.sql":      {Data: []byte("up 2")},
+				"migrations/0002_add_index.down.sql":    {Data: []byte("down 2")},
+				"migrations/0001_create_table.up.sql":   {Data: []byte("up 1")},
+				"migrations/0001_create_table.down.sql": {Data: []byte("down 1")},
+			},
+			expected: []migration{
+				{Version: 1, Name: "create_table", Up: "up 1", Down: "down 1"},
+				{Version: 2, Name: "add_index", Up: "up 2", Down: "down 2"},
+			},
+		},
+		{
+			name: "missing down file",
+			fsys: fstest.MapFS{
+				"migrations/0001_create_table.up.sql": {Data: []byte("up 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed file name",
+			fsys: fstest.MapFS{
+				"migrations/create_table.sql": {Data: []byte("up 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"migrations/0001_create_table.up.sql": {Data: []byte("up 1")},
+				"migrations/0001_drop_table.down.sql": {Data: []byte("down 1")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tc.fsys)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, migrations)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := loadMigrations(migrationsFS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		require.Equal(t, i+1, m.Version, "migration versions must be contiguous")
+	}
+}
@@ -0,0 +1,8 @@
+CREATE TABLE coin_rates (
+    id        BIGSERIAL PRIMARY KEY,
+    title     TEXT             NOT NULL,
+    cost      DOUBLE PRECISION NOT NULL,
+    actual_at TIMESTAMPTZ      NOT NULL
+);
+
+CREATE INDEX coin_rates_title_actual_at_idx ON coin_rates (title, actual_at DESC);
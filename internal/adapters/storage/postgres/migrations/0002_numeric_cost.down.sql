@@ -0,0 +1 @@
+ALTER TABLE coin_rates ALTER COLUMN cost TYPE DOUBLE PRECISION USING cost::DOUBLE PRECISION;
@@ -0,0 +1 @@
+ALTER TABLE coin_rates ALTER COLUMN cost TYPE NUMERIC USING cost::NUMERIC;
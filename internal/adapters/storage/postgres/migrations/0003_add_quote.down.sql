@@ -0,0 +1,5 @@
+DROP INDEX coin_rates_quote_title_actual_at_idx;
+DELETE FROM coin_rates WHERE quote <> 'USD';
+ALTER TABLE coin_rates DROP COLUMN quote;
+
+CREATE INDEX coin_rates_title_actual_at_idx ON coin_rates (title, actual_at DESC);
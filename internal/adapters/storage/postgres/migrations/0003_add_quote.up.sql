@@ -0,0 +1,6 @@
+-- Rates stored before quotes existed were all in USD.
+ALTER TABLE coin_rates ADD COLUMN quote TEXT NOT NULL DEFAULT 'USD';
+ALTER TABLE coin_rates ALTER COLUMN quote DROP DEFAULT;
+
+DROP INDEX coin_rates_title_actual_at_idx;
+CREATE INDEX coin_rates_quote_title_actual_at_idx ON coin_rates (quote, title, actual_at DESC);
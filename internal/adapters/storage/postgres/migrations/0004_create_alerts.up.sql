@@ -0,0 +1,24 @@
+CREATE TABLE alert_rules (
+    id        BIGSERIAL PRIMARY KEY,
+    title     TEXT        NOT NULL,
+    quote     TEXT        NOT NULL,
+    condition TEXT        NOT NULL CHECK (condition IN ('above', 'below', 'change')),
+    threshold NUMERIC     NOT NULL,
+    "window"  INTERVAL    NOT NULL,
+    cooldown  INTERVAL    NOT NULL,
+    firing    BOOLEAN     NOT NULL DEFAULT FALSE,
+    fired_at  TIMESTAMPTZ
+);
+
+CREATE TABLE alert_events (
+    id      BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT      NOT NULL REFERENCES alert_rules (id) ON DELETE CASCADE,
+    title   TEXT        NOT NULL,
+    quote   TEXT        NOT NULL,
+    state   TEXT        NOT NULL CHECK (state IN ('firing', 'resolved')),
+    cost    NUMERIC     NOT NULL,
+    value   NUMERIC     NOT NULL,
+    at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX alert_events_rule_id_id_idx ON alert_events (rule_id, id DESC);
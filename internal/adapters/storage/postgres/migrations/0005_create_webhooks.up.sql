@@ -0,0 +1,18 @@
+CREATE TABLE webhooks (
+    id         BIGSERIAL PRIMARY KEY,
+    url        TEXT        NOT NULL,
+    secret     TEXT        NOT NULL,
+    events     TEXT[]      NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE webhook_dead_letters (
+    id         BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT      NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id   TEXT        NOT NULL,
+    event_type TEXT        NOT NULL,
+    payload    BYTEA       NOT NULL,
+    attempts   INTEGER     NOT NULL,
+    last_error TEXT        NOT NULL,
+    failed_at  TIMESTAMPTZ NOT NULL
+);
@@ -1 +1,354 @@
 package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const sourceName = "postgres"
+
+// storeLockID serializes concurrent stores through pg_advisory_xact_lock.
+const storeLockID = 7305917432
+
+// Storage keeps the history of coin rates in the coin_rates table and alert
+// rules in the alert_rules and alert_events tables, whose schema is managed by
+// the embedded migrations (see MigrateUp).
+type Storage struct {
+	pool *pgxpool.Pool
+}
+
+var (
+	_ cases.Storage      = (*Storage)(nil)
+	_ cases.AlertStorage = (*Storage)(nil)
+)
+
+type Config struct {
+	DSN             string
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+	// MigrateOnStartup applies pending migrations in NewStorage.
+	MigrateOnStartup bool
+}
+
+func NewStorage(ctx context.Context, cfg Config) (*Storage, error) {
+	if cfg.DSN == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "dsn not set")
+	}
+
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "failed to parse dsn: %v", err)
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, storageError(err, "failed to create pool")
+	}
+
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, storageError(err, "failed to ping database")
+	}
+
+	storage := &Storage{
+		pool: pool,
+	}
+
+	if cfg.MigrateOnStartup {
+		if err = storage.MigrateUp(ctx); err != nil {
+			pool.Close()
+			return nil, errors.Wrap(err, "failed to migrate")
+		}
+	}
+
+	return storage, nil
+}
+
+func (s *Storage) Close() {
+	s.pool.Close()
+}
+
+func (s *Storage) Store(ctx context.Context, coins []*entities.Coin) error {
+	if len(coins) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+
+	for _, coin := range coins {
+		batch.Queue(
+			`INSERT INTO coin_rates (title, quote, cost, actual_at) VALUES ($1, $2, $3, $4) RETURNING id`,
+			coin.Title, coin.Quote, coin.Cost, coin.ActualAt,
+		).QueryRow(func(row pgx.Row) error {
+			return row.Scan(&coin.Seq)
+		})
+	}
+
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// Stores take their ids and commit one at a time, so that a reader
+		// paging by id never passes an id that is not committed yet.
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, storeLockID); err != nil {
+			return err
+		}
+
+		return tx.SendBatch(ctx, batch).Close()
+	})
+	if err != nil {
+		return storageError(err, "failed to insert coins")
+	}
+
+	return nil
+}
+
+func (s *Storage) GetQuotesList(ctx context.Context) ([]string, error) {
+	rows, err := s.pool.Query(ctx, `SELECT DISTINCT quote FROM coin_rates ORDER BY quote`)
+	if err != nil {
+		return nil, storageError(err, "failed to select quotes")
+	}
+
+	quotes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, storageError(err, "failed to scan quotes")
+	}
+
+	return quotes, nil
+}
+
+func (s *Storage) GetCoinsList(ctx context.Context, quote string) ([]string, error) {
+	rows, err := s.pool.Query(ctx, `SELECT DISTINCT title FROM coin_rates WHERE quote = $1 ORDER BY title`, quote)
+	if err != nil {
+		return nil, storageError(err, "failed to select titles")
+	}
+
+	titles, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, storageError(err, "failed to scan titles")
+	}
+
+	return titles, nil
+}
+
+func (s *Storage) GetActualCoin(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (title) title, quote, cost, actual_at
+		FROM coin_rates
+		WHERE title = ANY($1) AND quote = $2
+		ORDER BY title, actual_at DESC, id DESC`,
+		titles, quote,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select actual coins")
+	}
+
+	return collectCoins(rows)
+}
+
+func (s *Storage) GetHistoricalCoin(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	at time.Time,
+) ([]*entities.Coin, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (title) title, quote, cost, actual_at
+		FROM coin_rates
+		WHERE title = ANY($1) AND quote = $2 AND actual_at <= $3
+		ORDER BY title, actual_at DESC, id DESC`,
+		titles, quote, at,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select historical coins")
+	}
+
+	return collectCoins(rows)
+}
+
+func (s *Storage) GetRatesAfter(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	after entities.RateCursor,
+	limit int,
+) ([]*entities.Coin, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, quote, cost, actual_at
+		FROM coin_rates
+		WHERE title = ANY($1) AND quote = $2 AND id > $3
+		ORDER BY id
+		LIMIT $4`,
+		titles, quote, after.Seq, limit,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select rates")
+	}
+
+	coins, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Coin, error) {
+		coin := &entities.Coin{}
+		if err := row.Scan(&coin.Seq, &coin.Title, &coin.Quote, &coin.Cost, &coin.ActualAt); err != nil {
+			return nil, err
+		}
+
+		return coin, nil
+	})
+	if err != nil {
+		return nil, storageError(err, "failed to scan rates")
+	}
+
+	return coins, nil
+}
+
+// GetAggregateCoins folds the history of every title within period with
+// aggType. ActualAt of an aggregated coin is the time of the latest aggregated
+// point, titles without points in period are skipped.
+func (s *Storage) GetAggregateCoins(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	aggType string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	var aggExpr string
+
+	switch aggType {
+	case cases.AggTypeMax:
+		aggExpr = "MAX(cost)"
+	case cases.AggTypeMin:
+		aggExpr = "MIN(cost)"
+	case cases.AggTypeAvg:
+		aggExpr = fmt.Sprintf("ROUND(AVG(cost), %d)", entities.PriceScale)
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unknown aggregate type %q", aggType)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT title, quote, `+aggExpr+`, MAX(actual_at)
+		FROM coin_rates
+		WHERE title = ANY($1) AND quote = $2
+			AND ($3::timestamptz IS NULL OR actual_at >= $3)
+			AND ($4::timestamptz IS NULL OR actual_at <= $4)
+		GROUP BY title, quote
+		ORDER BY title`,
+		titles, quote, nullTime(period.From), nullTime(period.To),
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select aggregate coins")
+	}
+
+	return collectCoins(rows)
+}
+
+// GetCandles buckets the rates by interval aligned to the Unix epoch. Open and
+// close resolve equal times by insertion order like GetActualCoin does.
+func (s *Storage) GetCandles(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	interval entities.Interval,
+	period entities.Period,
+) ([]*entities.Candle, error) {
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT
+			title,
+			bucket,
+			(array_agg(cost ORDER BY actual_at, id))[1],
+			MAX(cost),
+			MIN(cost),
+			(array_agg(cost ORDER BY actual_at DESC, id DESC))[1]
+		FROM (
+			SELECT id, title, cost, actual_at,
+				to_timestamp(floor(extract(epoch FROM actual_at) / $2::bigint) * $2::bigint) AS bucket
+			FROM coin_rates
+			WHERE title = ANY($1) AND quote = $5
+				AND ($3::timestamptz IS NULL OR actual_at >= $3)
+				AND ($4::timestamptz IS NULL OR actual_at <= $4)
+		) AS points
+		GROUP BY title, bucket
+		ORDER BY title, bucket`,
+		titles, int64(time.Duration(interval)/time.Second), nullTime(period.From), nullTime(period.To), quote,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select candles")
+	}
+
+	candles, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Candle, error) {
+		candle := &entities.Candle{Quote: quote, Interval: interval}
+		if err := row.Scan(
+			&candle.Title, &candle.Start, &candle.Open, &candle.High, &candle.Low, &candle.Close,
+		); err != nil {
+			return nil, err
+		}
+
+		candle.Start = candle.Start.UTC()
+
+		return candle, nil
+	})
+	if err != nil {
+		return nil, storageError(err, "failed to scan candles")
+	}
+
+	return candles, nil
+}
+
+// nullTime maps an unbounded period end to NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func collectCoins(rows pgx.Rows) ([]*entities.Coin, error) {
+	coins, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Coin, error) {
+		coin := &entities.Coin{}
+		if err := row.Scan(&coin.Title, &coin.Quote, &coin.Cost, &coin.ActualAt); err != nil {
+			return nil, err
+		}
+
+		return coin, nil
+	})
+	if err != nil {
+		return nil, storageError(err, "failed to scan coins")
+	}
+
+	return coins, nil
+}
+
+// storageError wraps err into a domain error, marking connection failures and
+// timeouts retryable.
+func storageError(err error, format string, args ...any) error {
+	retryable := pgconn.SafeToRetry(err) || pgconn.Timeout(err)
+
+	return entities.NewStorageError(sourceName, retryable, errors.Wrapf(err, format, args...))
+}
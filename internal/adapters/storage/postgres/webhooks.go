@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+func (s *Storage) CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error) {
+	events := make([]string, 0, len(webhook.Events))
+	for _, eventType := range webhook.Events {
+		events = append(events, string(eventType))
+	}
+
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO webhooks (url, secret, events, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		webhook.URL, webhook.Secret, events, webhook.CreatedAt,
+	).Scan(&webhook.ID)
+	if err != nil {
+		return nil, storageError(err, "failed to insert webhook")
+	}
+
+	return &webhook, nil
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, url, secret, events, created_at
+		FROM webhooks
+		ORDER BY id`,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select webhooks")
+	}
+
+	webhooks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Webhook, error) {
+		var (
+			webhook entities.Webhook
+			events  []string
+		)
+
+		if err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &events, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		for _, eventType := range events {
+			webhook.Events = append(webhook.Events, entities.EventType(eventType))
+		}
+
+		return &webhook, nil
+	})
+	if err != nil {
+		return nil, storageError(err, "failed to scan webhooks")
+	}
+
+	return webhooks, nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id int64) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return storageError(err, "failed to delete webhook")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrNotFound, "webhook %d", id)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveDeadLetter(ctx context.Context, letter *entities.DeadLetter) error {
+	if letter.ID != 0 {
+		_, err := s.pool.Exec(ctx, `
+			UPDATE webhook_dead_letters SET attempts = $2, last_error = $3, failed_at = $4
+			WHERE id = $1`,
+			letter.ID, letter.Attempts, letter.LastError, letter.FailedAt,
+		)
+		if err != nil {
+			return storageError(err, "failed to update dead letter")
+		}
+
+		return nil
+	}
+
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO webhook_dead_letters (webhook_id, event_id, event_type, payload, attempts, last_error, failed_at)
+		SELECT id, $2, $3, $4, $5, $6, $7 FROM webhooks WHERE id = $1
+		RETURNING id`,
+		letter.WebhookID, letter.Delivery.EventID, letter.Delivery.EventType, letter.Delivery.Payload,
+		letter.Attempts, letter.LastError, letter.FailedAt,
+	).Scan(&letter.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return storageError(err, "failed to insert dead letter")
+	}
+
+	return nil
+}
+
+func (s *Storage) GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	rows, err := s.pool.Query(ctx, deadLettersQuery+`
+		ORDER BY id DESC
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select dead letters")
+	}
+
+	letters, err := pgx.CollectRows(rows, scanDeadLetter)
+	if err != nil {
+		return nil, storageError(err, "failed to scan dead letters")
+	}
+
+	return letters, nil
+}
+
+func (s *Storage) GetDeadLetter(ctx context.Context, id int64) (*entities.DeadLetter, error) {
+	rows, err := s.pool.Query(ctx, deadLettersQuery+`
+		WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return nil, storageError(err, "failed to select dead letter")
+	}
+
+	letter, err := pgx.CollectExactlyOneRow(rows, scanDeadLetter)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrapf(entities.ErrNotFound, "dead letter %d", id)
+	}
+
+	if err != nil {
+		return nil, storageError(err, "failed to scan dead letter")
+	}
+
+	return letter, nil
+}
+
+func (s *Storage) DeleteDeadLetter(ctx context.Context, id int64) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM webhook_dead_letters WHERE id = $1`, id)
+	if err != nil {
+		return storageError(err, "failed to delete dead letter")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrNotFound, "dead letter %d", id)
+	}
+
+	return nil
+}
+
+const deadLettersQuery = `
+	SELECT id, webhook_id, event_id, event_type, payload, attempts, last_error, failed_at
+	FROM webhook_dead_letters`
+
+func scanDeadLetter(row pgx.CollectableRow) (*entities.DeadLetter, error) {
+	var letter entities.DeadLetter
+	if err := row.Scan(
+		&letter.ID, &letter.WebhookID, &letter.Delivery.EventID, &letter.Delivery.EventType,
+		&letter.Delivery.Payload, &letter.Attempts, &letter.LastError, &letter.FailedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
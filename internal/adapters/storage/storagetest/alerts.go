@@ -0,0 +1,163 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// AlertFactory returns an empty alert storage. It is called once per subtest.
+type AlertFactory func(t *testing.T) cases.AlertStorage
+
+// RunAlerts runs the alert suite against the storages built by newStorage.
+func RunAlerts(t *testing.T, newStorage AlertFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, storage cases.AlertStorage)
+	}{
+		{name: "AlertRules", fn: testAlertRules},
+		{name: "AlertEvaluation", fn: testAlertEvaluation},
+		{name: "DeleteAlertRule", fn: testDeleteAlertRule},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newStorage(t))
+		})
+	}
+}
+
+func requireRule(t *testing.T, expected, actual *entities.AlertRule) {
+	t.Helper()
+
+	require.Equal(t, expected.ID, actual.ID)
+	require.Equal(t, expected.Title, actual.Title)
+	require.Equal(t, expected.Quote, actual.Quote)
+	require.Equal(t, expected.Condition, actual.Condition)
+	require.True(t, expected.Threshold.Equal(actual.Threshold), "expected Threshold %s, got %s", expected.Threshold, actual.Threshold)
+	require.Equal(t, expected.Window, actual.Window)
+	require.Equal(t, expected.Cooldown, actual.Cooldown)
+	require.Equal(t, expected.Firing, actual.Firing)
+	require.True(t, expected.FiredAt.Equal(actual.FiredAt), "expected FiredAt %s, got %s", expected.FiredAt, actual.FiredAt)
+}
+
+func testAlertRules(t *testing.T, storage cases.AlertStorage) {
+	ctx := context.Background()
+
+	rules, err := storage.GetAlertRules(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	above, err := storage.CreateAlertRule(ctx, entities.AlertRule{
+		Title: "BTC", Quote: usd, Condition: entities.AlertAbove, Threshold: price("100000.5"), Cooldown: time.Hour,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, above.ID)
+
+	change, err := storage.CreateAlertRule(ctx, entities.AlertRule{
+		Title: "ETH", Quote: "EUR", Condition: entities.AlertChange, Threshold: price("5"), Window: 24 * time.Hour,
+	})
+	require.NoError(t, err)
+	require.Greater(t, change.ID, above.ID)
+
+	rules, err = storage.GetAlertRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	requireRule(t, above, rules[0])
+	requireRule(t, change, rules[1])
+}
+
+func testAlertEvaluation(t *testing.T, storage cases.AlertStorage) {
+	ctx := context.Background()
+
+	first, err := storage.CreateAlertRule(ctx, entities.AlertRule{
+		Title: "BTC", Quote: usd, Condition: entities.AlertAbove, Threshold: price("100"),
+	})
+	require.NoError(t, err)
+
+	second, err := storage.CreateAlertRule(ctx, entities.AlertRule{
+		Title: "ETH", Quote: usd, Condition: entities.AlertBelow, Threshold: price("10"),
+	})
+	require.NoError(t, err)
+
+	first.Firing, first.FiredAt = true, at(1)
+	firing := &entities.AlertEvent{
+		RuleID: first.ID, Title: "BTC", Quote: usd, State: entities.AlertFiring, Cost: price("150"), Value: price("150"), At: at(1),
+	}
+	require.NoError(t, storage.SaveAlertEvaluation(ctx, []*entities.AlertRule{first}, []*entities.AlertEvent{firing}))
+	require.NotZero(t, firing.ID)
+
+	first.Firing = false
+	resolved := &entities.AlertEvent{
+		RuleID: first.ID, Title: "BTC", Quote: usd, State: entities.AlertResolved, Cost: price("90"), Value: price("90"), At: at(2),
+	}
+	other := &entities.AlertEvent{
+		RuleID: second.ID, Title: "ETH", Quote: usd, State: entities.AlertFiring, Cost: price("9.5"), Value: price("9.5"), At: at(2),
+	}
+	second.Firing, second.FiredAt = true, at(2)
+	require.NoError(t, storage.SaveAlertEvaluation(ctx, []*entities.AlertRule{first, second}, []*entities.AlertEvent{resolved, other}))
+
+	rules, err := storage.GetAlertRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	requireRule(t, first, rules[0])
+	requireRule(t, second, rules[1])
+
+	events, err := storage.GetAlertEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, []int64{other.ID, resolved.ID, firing.ID}, []int64{events[0].ID, events[1].ID, events[2].ID})
+	require.Equal(t, entities.AlertResolved, events[1].State)
+	require.True(t, price("90").Equal(events[1].Cost))
+	require.True(t, at(2).Equal(events[1].At))
+
+	events, err = storage.GetAlertEvents(ctx, first.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, resolved.ID, events[0].ID)
+}
+
+func testDeleteAlertRule(t *testing.T, storage cases.AlertStorage) {
+	ctx := context.Background()
+
+	rule, err := storage.CreateAlertRule(ctx, entities.AlertRule{
+		Title: "BTC", Quote: usd, Condition: entities.AlertAbove, Threshold: price("100"),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SaveAlertEvaluation(ctx, nil, []*entities.AlertEvent{
+		{RuleID: rule.ID, Title: "BTC", Quote: usd, State: entities.AlertFiring, Cost: price("150"), Value: price("150"), At: at(1)},
+	}))
+
+	require.NoError(t, storage.DeleteAlertRule(ctx, rule.ID))
+	require.ErrorIs(t, storage.DeleteAlertRule(ctx, rule.ID), entities.ErrNotFound)
+
+	rules, err := storage.GetAlertRules(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	events, err := storage.GetAlertEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// Evaluations racing with the deletion are dropped.
+	rule.Firing = true
+	require.NoError(t, storage.SaveAlertEvaluation(ctx, []*entities.AlertRule{rule}, []*entities.AlertEvent{
+		{RuleID: rule.ID, Title: "BTC", Quote: usd, State: entities.AlertFiring, Cost: price("150"), Value: price("150"), At: at(2)},
+	}))
+
+	rules, err = storage.GetAlertRules(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	events, err = storage.GetAlertEvents(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -0,0 +1,636 @@
+// Package storagetest provides the behavioral test suite every cases.Storage
+// implementation must pass. Adapters call Run from their own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) cases.Storage {
+//			return memory.NewStorage()
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// Factory returns an empty storage. It is called once per subtest.
+type Factory func(t *testing.T) cases.Storage
+
+// Run runs the whole suite against the storages built by newStorage.
+func Run(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, storage cases.Storage)
+	}{
+		{name: "EmptyStorage", fn: testEmptyStorage},
+		{name: "EmptyInput", fn: testEmptyInput},
+		{name: "CoinsList", fn: testCoinsList},
+		{name: "HistoryOrdering", fn: testHistoryOrdering},
+		{name: "ActualCoin", fn: testActualCoin},
+		{name: "HistoricalCoin", fn: testHistoricalCoin},
+		{name: "RatesAfter", fn: testRatesAfter},
+		{name: "RatesAfterOutOfOrder", fn: testRatesAfterOutOfOrder},
+		{name: "AggregateCoins", fn: testAggregateCoins},
+		{name: "AggregateWindow", fn: testAggregateWindow},
+		{name: "UnknownAggregateType", fn: testUnknownAggregateType},
+		{name: "Candles", fn: testCandles},
+		{name: "Precision", fn: testPrecision},
+		{name: "Quotes", fn: testQuotes},
+		{name: "UnknownTitles", fn: testUnknownTitles},
+		{name: "DuplicateStores", fn: testDuplicateStores},
+		{name: "ConcurrentWriters", fn: testConcurrentWriters},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newStorage(t))
+		})
+	}
+}
+
+const usd = entities.DefaultQuote
+
+// baseTime is truncated so that every adapter can store it without losing precision.
+var baseTime = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+func at(minutes int) time.Time {
+	return baseTime.Add(time.Duration(minutes) * time.Minute)
+}
+
+func price(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func requireCoins(t *testing.T, expected, actual []*entities.Coin) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+
+	for i := range expected {
+		require.Equal(t, expected[i].Title, actual[i].Title, "coin %d", i)
+		require.Equal(t, expected[i].Quote, actual[i].Quote, "coin %d", i)
+		require.True(t, expected[i].Cost.Equal(actual[i].Cost),
+			"coin %d (%s): expected Cost %s, got %s", i, expected[i].Title, expected[i].Cost, actual[i].Cost)
+		require.True(t, expected[i].ActualAt.Equal(actual[i].ActualAt),
+			"coin %d (%s): expected ActualAt %s, got %s", i, expected[i].Title, expected[i].ActualAt, actual[i].ActualAt)
+	}
+}
+
+func testEmptyStorage(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	quotes, err := storage.GetQuotesList(ctx)
+	require.NoError(t, err)
+	require.Empty(t, quotes)
+
+	titles, err := storage.GetCoinsList(ctx, usd)
+	require.NoError(t, err)
+	require.Empty(t, titles)
+
+	coins, err := storage.GetActualCoin(ctx, []string{"BTC"}, usd)
+	require.NoError(t, err)
+	require.Empty(t, coins)
+
+	coins, err = storage.GetAggregateCoins(ctx, []string{"BTC"}, usd, cases.AggTypeMax, entities.Period{})
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+func testEmptyInput(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, nil))
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{}))
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("1"), ActualAt: at(0)}}))
+
+	coins, err := storage.GetActualCoin(ctx, nil, usd)
+	require.NoError(t, err)
+	require.Empty(t, coins)
+
+	coins, err = storage.GetAggregateCoins(ctx, []string{}, usd, cases.AggTypeAvg, entities.Period{})
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+func testCoinsList(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "TON", Quote: usd, Cost: price("1"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("1"), ActualAt: at(0)},
+		{Title: "ETH", Quote: usd, Cost: price("1"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("2"), ActualAt: at(1)},
+	}))
+
+	titles, err := storage.GetCoinsList(ctx, usd)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC", "ETH", "TON"}, titles)
+}
+
+func testHistoryOrdering(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	// Points arrive out of order across several batches; the latest one by
+	// ActualAt wins regardless of the order they were stored in.
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("30"), ActualAt: at(3)}}))
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("10"), ActualAt: at(1)},
+		{Title: "BTC", Quote: usd, Cost: price("20"), ActualAt: at(2)},
+	}))
+
+	coins, err := storage.GetActualCoin(ctx, []string{"BTC"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("30"), ActualAt: at(3)}}, coins)
+
+	// Points with equal ActualAt are resolved in favor of the one stored last.
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("31"), ActualAt: at(3)}}))
+
+	coins, err = storage.GetActualCoin(ctx, []string{"BTC"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("31"), ActualAt: at(3)}}, coins)
+}
+
+func testActualCoin(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "ETH", Quote: usd, Cost: price("5"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("150"), ActualAt: at(5)},
+		{Title: "ETH", Quote: usd, Cost: price("7"), ActualAt: at(2)},
+		{Title: "TON", Quote: usd, Cost: price("1"), ActualAt: at(1)},
+	}))
+
+	coins, err := storage.GetActualCoin(ctx, []string{"TON", "BTC", "ETH"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("150"), ActualAt: at(5)},
+		{Title: "ETH", Quote: usd, Cost: price("7"), ActualAt: at(2)},
+		{Title: "TON", Quote: usd, Cost: price("1"), ActualAt: at(1)},
+	}, coins)
+
+	coins, err = storage.GetActualCoin(ctx, []string{"ETH"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "ETH", Quote: usd, Cost: price("7"), ActualAt: at(2)}}, coins)
+}
+
+func testHistoricalCoin(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("120"), ActualAt: at(2)},
+		{Title: "BTC", Quote: usd, Cost: price("130"), ActualAt: at(2)},
+		{Title: "BTC", Quote: usd, Cost: price("150"), ActualAt: at(5)},
+		{Title: "ETH", Quote: usd, Cost: price("7"), ActualAt: at(3)},
+		{Title: "BTC", Quote: "EUR", Cost: price("90"), ActualAt: at(1)},
+	}))
+
+	coins, err := storage.GetHistoricalCoin(ctx, []string{"ETH", "BTC"}, usd, at(3))
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("130"), ActualAt: at(2)},
+		{Title: "ETH", Quote: usd, Cost: price("7"), ActualAt: at(3)},
+	}, coins)
+
+	coins, err = storage.GetHistoricalCoin(ctx, []string{"ETH", "BTC"}, usd, at(1))
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)}}, coins)
+
+	coins, err = storage.GetHistoricalCoin(ctx, []string{"BTC"}, "EUR", at(10))
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: "EUR", Cost: price("90"), ActualAt: at(1)}}, coins)
+
+	coins, err = storage.GetHistoricalCoin(ctx, []string{"BTC"}, usd, at(-1))
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+func testRatesAfter(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	batch := []*entities.Coin{
+		{Title: "ETH", Quote: usd, Cost: price("7"), ActualAt: at(1)},
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("110"), ActualAt: at(1)},
+		{Title: "SOL", Quote: usd, Cost: price("1"), ActualAt: at(1)},
+		{Title: "ETH", Quote: usd, Cost: price("8"), ActualAt: at(2)},
+		{Title: "BTC", Quote: "EUR", Cost: price("90"), ActualAt: at(1)},
+	}
+	require.NoError(t, storage.Store(ctx, batch))
+
+	for i := 1; i < len(batch); i++ {
+		require.Greater(t, batch[i].Seq, batch[i-1].Seq, "coin %d", i)
+	}
+
+	titles := []string{"ETH", "BTC"}
+
+	// Rates follow the order they were stored in, not their actual time.
+	expected := []*entities.Coin{batch[0], batch[1], batch[2], batch[4]}
+
+	coins, err := storage.GetRatesAfter(ctx, titles, usd, entities.RateCursor{}, 10)
+	require.NoError(t, err)
+	requireCoins(t, expected, coins)
+
+	for i := range expected {
+		require.Equal(t, entities.CursorOf(expected[i]), entities.CursorOf(coins[i]), "coin %d", i)
+	}
+
+	coins, err = storage.GetRatesAfter(ctx, titles, usd, entities.CursorOf(batch[1]), 1)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("110"), ActualAt: at(1)}}, coins)
+
+	coins, err = storage.GetRatesAfter(ctx, titles, usd, entities.CursorOf(coins[0]), 10)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "ETH", Quote: usd, Cost: price("8"), ActualAt: at(2)}}, coins)
+
+	coins, err = storage.GetRatesAfter(ctx, titles, usd, entities.CursorOf(coins[0]), 10)
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+// testRatesAfterOutOfOrder stores rates older than the ones already read, as
+// a backfill or a lagging provider does: following the cursor must still
+// reach them, both from the coins Store was given, as a live stream sees
+// them, and from GetRatesAfter, as a resumed stream reads them.
+func testRatesAfterOutOfOrder(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(5)},
+	}))
+
+	coins, err := storage.GetRatesAfter(ctx, []string{"BTC"}, usd, entities.RateCursor{}, 10)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+
+	cursor := entities.CursorOf(coins[0])
+
+	late := []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("90"), ActualAt: at(1)},
+		{Title: "BTC", Quote: usd, Cost: price("95"), ActualAt: at(1)},
+	}
+	require.NoError(t, storage.Store(ctx, late))
+
+	for _, coin := range late {
+		require.Positive(t, entities.CursorOf(coin).Compare(cursor), "live coin %s", coin.Cost)
+	}
+
+	// Both points share their title and actual time and are still two rates.
+	coins, err = storage.GetRatesAfter(ctx, []string{"BTC"}, usd, cursor, 10)
+	require.NoError(t, err)
+	requireCoins(t, late, coins)
+	require.Equal(t, entities.CursorOf(late[0]), entities.CursorOf(coins[0]))
+	require.Equal(t, entities.CursorOf(late[1]), entities.CursorOf(coins[1]))
+}
+
+func testAggregateCoins(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("400"), ActualAt: at(1)},
+		{Title: "BTC", Quote: usd, Cost: price("250"), ActualAt: at(3)},
+		{Title: "BTC", Quote: usd, Cost: price("50"), ActualAt: at(2)},
+		{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(4)},
+	}))
+
+	testTable := []struct {
+		aggType  string
+		expected []*entities.Coin
+	}{
+		{
+			aggType: cases.AggTypeMax,
+			expected: []*entities.Coin{
+				{Title: "BTC", Quote: usd, Cost: price("400"), ActualAt: at(3)},
+				{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(4)},
+			},
+		},
+		{
+			aggType: cases.AggTypeMin,
+			expected: []*entities.Coin{
+				{Title: "BTC", Quote: usd, Cost: price("50"), ActualAt: at(3)},
+				{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(4)},
+			},
+		},
+		{
+			aggType: cases.AggTypeAvg,
+			expected: []*entities.Coin{
+				{Title: "BTC", Quote: usd, Cost: price("200"), ActualAt: at(3)},
+				{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(4)},
+			},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.aggType, func(t *testing.T) {
+			coins, err := storage.GetAggregateCoins(ctx, []string{"ETH", "BTC"}, usd, tc.aggType, entities.Period{})
+			require.NoError(t, err)
+			requireCoins(t, tc.expected, coins)
+		})
+	}
+}
+
+func testAggregateWindow(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("400"), ActualAt: at(1)},
+		{Title: "BTC", Quote: usd, Cost: price("50"), ActualAt: at(2)},
+		{Title: "BTC", Quote: usd, Cost: price("250"), ActualAt: at(3)},
+		{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(0)},
+	}))
+
+	testTable := []struct {
+		name     string
+		aggType  string
+		period   entities.Period
+		expected []*entities.Coin
+	}{
+		{
+			name:    "bounds are inclusive",
+			aggType: cases.AggTypeAvg,
+			period:  entities.Period{From: at(1), To: at(3)},
+			expected: []*entities.Coin{
+				{Title: "BTC", Quote: usd, Cost: price("233.333333333333333333"), ActualAt: at(3)},
+			},
+		},
+		{
+			name:    "open start",
+			aggType: cases.AggTypeMin,
+			period:  entities.Period{To: at(1)},
+			expected: []*entities.Coin{
+				{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(1)},
+				{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(0)},
+			},
+		},
+		{
+			name:    "open end",
+			aggType: cases.AggTypeMax,
+			period:  entities.Period{From: at(2)},
+			expected: []*entities.Coin{
+				{Title: "BTC", Quote: usd, Cost: price("250"), ActualAt: at(3)},
+			},
+		},
+		{
+			name:     "no points in window",
+			aggType:  cases.AggTypeMax,
+			period:   entities.Period{From: at(10), To: at(20)},
+			expected: []*entities.Coin{},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			coins, err := storage.GetAggregateCoins(ctx, []string{"BTC", "ETH"}, usd, tc.aggType, tc.period)
+			require.NoError(t, err)
+			requireCoins(t, tc.expected, coins)
+		})
+	}
+}
+
+func testUnknownAggregateType(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("1"), ActualAt: at(0)}}))
+
+	coins, err := storage.GetAggregateCoins(ctx, []string{"BTC"}, usd, "median", entities.Period{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.Nil(t, coins)
+}
+
+func testCandles(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("130"), ActualAt: at(2)},
+		{Title: "BTC", Quote: usd, Cost: price("90"), ActualAt: at(1)},
+		{Title: "BTC", Quote: usd, Cost: price("110"), ActualAt: at(4)},
+		{Title: "BTC", Quote: usd, Cost: price("200"), ActualAt: at(5)},
+		{Title: "BTC", Quote: usd, Cost: price("120"), ActualAt: at(12)},
+		{Title: "ETH", Quote: usd, Cost: price("3"), ActualAt: at(3)},
+	}))
+
+	// Equal times resolve in insertion order: opens first, closes last.
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("111"), ActualAt: at(4)}}))
+
+	candles, err := storage.GetCandles(ctx, []string{"ETH", "BTC", "DOGE"}, usd, entities.Interval5m, entities.Period{})
+	require.NoError(t, err)
+	requireCandles(t, []*entities.Candle{
+		{Title: "BTC", Quote: usd, Interval: entities.Interval5m, Start: at(0), Open: price("100"), High: price("130"), Low: price("90"), Close: price("111")},
+		{Title: "BTC", Quote: usd, Interval: entities.Interval5m, Start: at(5), Open: price("200"), High: price("200"), Low: price("200"), Close: price("200")},
+		{Title: "BTC", Quote: usd, Interval: entities.Interval5m, Start: at(10), Open: price("120"), High: price("120"), Low: price("120"), Close: price("120")},
+		{Title: "ETH", Quote: usd, Interval: entities.Interval5m, Start: at(0), Open: price("3"), High: price("3"), Low: price("3"), Close: price("3")},
+	}, candles)
+
+	candles, err = storage.GetCandles(ctx, []string{"BTC"}, usd, entities.Interval1h, entities.Period{From: at(2), To: at(5)})
+	require.NoError(t, err)
+	requireCandles(t, []*entities.Candle{
+		{Title: "BTC", Quote: usd, Interval: entities.Interval1h, Start: at(0), Open: price("130"), High: price("200"), Low: price("110"), Close: price("200")},
+	}, candles)
+
+	candles, err = storage.GetCandles(ctx, []string{"BTC"}, usd, entities.Interval(time.Second), entities.Period{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.Nil(t, candles)
+}
+
+func testPrecision(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("98765432109876543210.123456789012345678"), ActualAt: at(0)},
+		{Title: "SHIB", Quote: usd, Cost: price("0.000000000000000001"), ActualAt: at(0)},
+		{Title: "ETH", Quote: usd, Cost: price("0.1"), ActualAt: at(0)},
+		{Title: "ETH", Quote: usd, Cost: price("0.2"), ActualAt: at(1)},
+		{Title: "ETH", Quote: usd, Cost: price("0.2"), ActualAt: at(2)},
+	}))
+
+	coins, err := storage.GetActualCoin(ctx, []string{"BTC", "SHIB"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("98765432109876543210.123456789012345678"), ActualAt: at(0)},
+		{Title: "SHIB", Quote: usd, Cost: price("0.000000000000000001"), ActualAt: at(0)},
+	}, coins)
+
+	// Averages are rounded to entities.PriceScale digits rather than drifting.
+	coins, err = storage.GetAggregateCoins(ctx, []string{"ETH"}, usd, cases.AggTypeAvg, entities.Period{})
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "ETH", Quote: usd, Cost: price("0.166666666666666667"), ActualAt: at(2)}}, coins)
+
+	coins, err = storage.GetAggregateCoins(ctx, []string{"ETH"}, usd, cases.AggTypeAvg, entities.Period{To: at(1)})
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "ETH", Quote: usd, Cost: price("0.15"), ActualAt: at(1)}}, coins)
+}
+
+func testQuotes(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: "EUR", Cost: price("90"), ActualAt: at(1)},
+		{Title: "BTC", Quote: "EUR", Cost: price("92"), ActualAt: at(2)},
+		{Title: "ETH", Quote: "BTC", Cost: price("0.05"), ActualAt: at(0)},
+	}))
+
+	quotes, err := storage.GetQuotesList(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC", "EUR", usd}, quotes)
+
+	titles, err := storage.GetCoinsList(ctx, "EUR")
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC"}, titles)
+
+	// Every query only sees the rates of the quote it asks for.
+	coins, err := storage.GetActualCoin(ctx, []string{"BTC", "ETH"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)}}, coins)
+
+	coins, err = storage.GetActualCoin(ctx, []string{"BTC", "ETH"}, "EUR")
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: "EUR", Cost: price("92"), ActualAt: at(2)}}, coins)
+
+	coins, err = storage.GetAggregateCoins(ctx, []string{"BTC"}, "EUR", cases.AggTypeAvg, entities.Period{})
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: "EUR", Cost: price("91"), ActualAt: at(2)}}, coins)
+
+	candles, err := storage.GetCandles(ctx, []string{"ETH"}, "BTC", entities.Interval1h, entities.Period{})
+	require.NoError(t, err)
+	requireCandles(t, []*entities.Candle{
+		{Title: "ETH", Quote: "BTC", Interval: entities.Interval1h, Start: at(0),
+			Open: price("0.05"), High: price("0.05"), Low: price("0.05"), Close: price("0.05")},
+	}, candles)
+
+	coins, err = storage.GetActualCoin(ctx, []string{"BTC"}, "GBP")
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+func requireCandles(t *testing.T, expected, actual []*entities.Candle) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+
+	for i := range expected {
+		e, a := expected[i], actual[i]
+
+		require.Equal(t, e.Title, a.Title, "candle %d", i)
+		require.Equal(t, e.Quote, a.Quote, "candle %d", i)
+		require.Equal(t, e.Interval, a.Interval, "candle %d", i)
+		require.True(t, e.Start.Equal(a.Start), "candle %d: expected Start %s, got %s", i, e.Start, a.Start)
+		require.Equal(t, time.UTC, a.Start.Location(), "candle %d", i)
+
+		for _, p := range []struct {
+			name             string
+			expected, actual decimal.Decimal
+		}{
+			{name: "Open", expected: e.Open, actual: a.Open},
+			{name: "High", expected: e.High, actual: a.High},
+			{name: "Low", expected: e.Low, actual: a.Low},
+			{name: "Close", expected: e.Close, actual: a.Close},
+		} {
+			require.True(t, p.expected.Equal(p.actual),
+				"candle %d (%s): expected %s %s, got %s", i, e.Title, p.name, p.expected, p.actual)
+		}
+	}
+}
+
+func testUnknownTitles(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("1"), ActualAt: at(0)}}))
+
+	coins, err := storage.GetActualCoin(ctx, []string{"DOGE", "BTC", "XRP"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("1"), ActualAt: at(0)}}, coins)
+
+	coins, err = storage.GetAggregateCoins(ctx, []string{"DOGE"}, usd, cases.AggTypeMin, entities.Period{})
+	require.NoError(t, err)
+	require.Empty(t, coins)
+}
+
+func testDuplicateStores(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	batch := []*entities.Coin{
+		{Title: "BTC", Quote: usd, Cost: price("100"), ActualAt: at(0)},
+		{Title: "BTC", Quote: usd, Cost: price("300"), ActualAt: at(1)},
+	}
+
+	require.NoError(t, storage.Store(ctx, batch))
+	require.NoError(t, storage.Store(ctx, batch))
+
+	titles, err := storage.GetCoinsList(ctx, usd)
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC"}, titles)
+
+	coins, err := storage.GetActualCoin(ctx, []string{"BTC", "BTC"}, usd)
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("300"), ActualAt: at(1)}}, coins)
+
+	// Duplicates are kept as history points and weigh equally in the average.
+	coins, err = storage.GetAggregateCoins(ctx, []string{"BTC"}, usd, cases.AggTypeAvg, entities.Period{})
+	require.NoError(t, err)
+	requireCoins(t, []*entities.Coin{{Title: "BTC", Quote: usd, Cost: price("200"), ActualAt: at(1)}}, coins)
+}
+
+func testConcurrentWriters(t *testing.T, storage cases.Storage) {
+	ctx := context.Background()
+
+	const writers, points = 8, 20
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, writers*points*2)
+
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for p := 1; p <= points; p++ {
+				errs <- storage.Store(ctx, []*entities.Coin{
+					{Title: fmt.Sprintf("COIN%d", w), Quote: usd, Cost: decimal.NewFromInt(int64(p)), ActualAt: at(p)},
+					{Title: "SHARED", Quote: usd, Cost: decimal.NewFromInt(int64(p)), ActualAt: at(p)},
+				})
+
+				_, err := storage.GetActualCoin(ctx, []string{"SHARED"}, usd)
+				errs <- err
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	titles, err := storage.GetCoinsList(ctx, usd)
+	require.NoError(t, err)
+	require.Len(t, titles, writers+1)
+
+	coins, err := storage.GetAggregateCoins(ctx, titles, usd, cases.AggTypeAvg, entities.Period{})
+	require.NoError(t, err)
+	require.Len(t, coins, writers+1)
+
+	for _, coin := range coins {
+		require.True(t, decimal.NewFromFloat(float64(points+1)/2).Equal(coin.Cost), coin.Title)
+		require.True(t, at(points).Equal(coin.ActualAt), coin.Title)
+	}
+}
@@ -0,0 +1,169 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// WebhookFactory returns an empty webhook storage. It is called once per subtest.
+type WebhookFactory func(t *testing.T) cases.WebhookStorage
+
+// RunWebhooks runs the webhook suite against the storages built by newStorage.
+func RunWebhooks(t *testing.T, newStorage WebhookFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, storage cases.WebhookStorage)
+	}{
+		{name: "Webhooks", fn: testWebhooks},
+		{name: "DeadLetters", fn: testDeadLetters},
+		{name: "DeleteWebhook", fn: testDeleteWebhook},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newStorage(t))
+		})
+	}
+}
+
+func requireWebhook(t *testing.T, expected, actual *entities.Webhook) {
+	t.Helper()
+
+	require.Equal(t, expected.ID, actual.ID)
+	require.Equal(t, expected.URL, actual.URL)
+	require.Equal(t, expected.Secret, actual.Secret)
+	require.ElementsMatch(t, expected.Events, actual.Events)
+	require.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "expected CreatedAt %s, got %s", expected.CreatedAt, actual.CreatedAt)
+}
+
+func requireDeadLetter(t *testing.T, expected, actual *entities.DeadLetter) {
+	t.Helper()
+
+	require.Equal(t, expected.ID, actual.ID)
+	require.Equal(t, expected.WebhookID, actual.WebhookID)
+	require.Equal(t, expected.Delivery, actual.Delivery)
+	require.Equal(t, expected.Attempts, actual.Attempts)
+	require.Equal(t, expected.LastError, actual.LastError)
+	require.True(t, expected.FailedAt.Equal(actual.FailedAt), "expected FailedAt %s, got %s", expected.FailedAt, actual.FailedAt)
+}
+
+func createWebhook(t *testing.T, storage cases.WebhookStorage, events ...entities.EventType) *entities.Webhook {
+	t.Helper()
+
+	webhook, err := storage.CreateWebhook(context.Background(), entities.Webhook{
+		URL: "https://example.com/hook", Secret: "s3cret", Events: events, CreatedAt: time.Now().Truncate(time.Microsecond),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, webhook.ID)
+
+	return webhook
+}
+
+func deadLetter(webhookID int64, eventID string) *entities.DeadLetter {
+	return &entities.DeadLetter{
+		WebhookID: webhookID,
+		Delivery:  entities.Delivery{EventID: eventID, EventType: entities.EventRatesActualized, Payload: []byte(`{"id":"` + eventID + `"}`)},
+		Attempts:  5,
+		LastError: "status 503",
+		FailedAt:  time.Now().Truncate(time.Microsecond),
+	}
+}
+
+func testWebhooks(t *testing.T, storage cases.WebhookStorage) {
+	ctx := context.Background()
+
+	webhooks, err := storage.GetWebhooks(ctx)
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+
+	all := createWebhook(t, storage)
+	alerts := createWebhook(t, storage, entities.EventAlertFired, entities.EventAlertResolved)
+	require.Greater(t, alerts.ID, all.ID)
+
+	webhooks, err = storage.GetWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	requireWebhook(t, all, webhooks[0])
+	requireWebhook(t, alerts, webhooks[1])
+}
+
+func testDeadLetters(t *testing.T, storage cases.WebhookStorage) {
+	ctx := context.Background()
+
+	webhook := createWebhook(t, storage)
+
+	first := deadLetter(webhook.ID, "first")
+	require.NoError(t, storage.SaveDeadLetter(ctx, first))
+	require.NotZero(t, first.ID)
+
+	second := deadLetter(webhook.ID, "second")
+	require.NoError(t, storage.SaveDeadLetter(ctx, second))
+	require.Greater(t, second.ID, first.ID)
+
+	letters, err := storage.GetDeadLetters(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	requireDeadLetter(t, second, letters[0])
+	requireDeadLetter(t, first, letters[1])
+
+	letters, err = storage.GetDeadLetters(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	requireDeadLetter(t, second, letters[0])
+
+	first.Attempts++
+	first.LastError = "status 500"
+	first.FailedAt = first.FailedAt.Add(time.Minute)
+	require.NoError(t, storage.SaveDeadLetter(ctx, first))
+
+	letter, err := storage.GetDeadLetter(ctx, first.ID)
+	require.NoError(t, err)
+	requireDeadLetter(t, first, letter)
+
+	require.NoError(t, storage.DeleteDeadLetter(ctx, first.ID))
+
+	_, err = storage.GetDeadLetter(ctx, first.ID)
+	require.ErrorIs(t, err, entities.ErrNotFound)
+	require.ErrorIs(t, storage.DeleteDeadLetter(ctx, first.ID), entities.ErrNotFound)
+
+	orphan := deadLetter(webhook.ID+1, "orphan")
+	require.NoError(t, storage.SaveDeadLetter(ctx, orphan))
+
+	letters, err = storage.GetDeadLetters(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	requireDeadLetter(t, second, letters[0])
+}
+
+func testDeleteWebhook(t *testing.T, storage cases.WebhookStorage) {
+	ctx := context.Background()
+
+	deleted := createWebhook(t, storage)
+	kept := createWebhook(t, storage)
+
+	require.NoError(t, storage.SaveDeadLetter(ctx, deadLetter(deleted.ID, "deleted")))
+
+	keptLetter := deadLetter(kept.ID, "kept")
+	require.NoError(t, storage.SaveDeadLetter(ctx, keptLetter))
+
+	require.NoError(t, storage.DeleteWebhook(ctx, deleted.ID))
+	require.ErrorIs(t, storage.DeleteWebhook(ctx, deleted.ID), entities.ErrNotFound)
+
+	webhooks, err := storage.GetWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	requireWebhook(t, kept, webhooks[0])
+
+	letters, err := storage.GetDeadLetters(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	requireDeadLetter(t, keptLetter, letters[0])
+}
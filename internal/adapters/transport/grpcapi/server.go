@@ -0,0 +1,294 @@
+// Package grpcapi serves the rates API over gRPC as rates.v1.RatesService.
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ratesv1 "crypto-project/api/rates/v1"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+	"crypto-project/internal/scheduler"
+)
+
+const (
+	DefaultRequestTimeout = 15 * time.Second
+
+	// errorDomain qualifies the entities.Code sent as the ErrorInfo reason.
+	errorDomain = "crypto-project"
+)
+
+// RatesService is the part of cases.Service the API exposes.
+type RatesService interface {
+	GetLastRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error)
+	GetMaxRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetMinRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetAvgRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	ActualizeRates(ctx context.Context) error
+	GetRatesAfter(
+		ctx context.Context,
+		titles []string,
+		quote string,
+		after entities.RateCursor,
+		limit int,
+	) ([]*entities.Coin, error)
+}
+
+var _ RatesService = (*cases.Service)(nil)
+
+// RatesFeed is the part of cases.Feed WatchRates uses.
+type RatesFeed interface {
+	Subscribe(buffer int) *cases.Subscription
+}
+
+var _ RatesFeed = (*cases.Feed)(nil)
+
+type Config struct {
+	// RequestTimeout bounds every unary call but ActualizeRates, and every
+	// page of history WatchRates reads.
+	RequestTimeout time.Duration
+	// Feed, if set, enables WatchRates.
+	Feed RatesFeed
+	// Actualize, if set, serves ActualizeRates instead of the service, such as
+	// scheduler.Scheduler.RunNow so that the runs it triggers never overlap
+	// the scheduled ones and are bounded the same way. A run refused with
+	// scheduler.ErrAlreadyRunning fails with codes.Aborted.
+	Actualize func(ctx context.Context) error
+}
+
+type server struct {
+	ratesv1.UnimplementedRatesServiceServer
+
+	service   RatesService
+	feed      RatesFeed
+	actualize func(ctx context.Context) error
+	timeout   time.Duration
+}
+
+// NewServer returns a gRPC server serving service. Failed calls carry the
+// status code entities.GRPCCode maps their error to, and an ErrorInfo whose
+// reason is the entities.Code and whose metadata lists the titles at fault.
+func NewServer(service RatesService, cfg Config, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if service == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "service not set")
+	}
+
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+
+	if cfg.Actualize == nil {
+		cfg.Actualize = service.ActualizeRates
+	}
+
+	s := &server{
+		service:   service,
+		feed:      cfg.Feed,
+		actualize: cfg.Actualize,
+		timeout:   cfg.RequestTimeout,
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	)
+
+	grpcServer := grpc.NewServer(opts...)
+	ratesv1.RegisterRatesServiceServer(grpcServer, s)
+
+	return grpcServer, nil
+}
+
+func (s *server) GetLastRates(
+	ctx context.Context,
+	req *ratesv1.GetLastRatesRequest,
+) (*ratesv1.GetLastRatesResponse, error) {
+	coins, err := s.service.GetLastRates(ctx, req.GetTitles(), req.GetQuote())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ratesv1.GetLastRatesResponse{Rates: newRates(coins)}, nil
+}
+
+func (s *server) GetMaxRates(
+	ctx context.Context,
+	req *ratesv1.GetAggregateRatesRequest,
+) (*ratesv1.GetAggregateRatesResponse, error) {
+	return aggregate(ctx, req, s.service.GetMaxRates)
+}
+
+func (s *server) GetMinRates(
+	ctx context.Context,
+	req *ratesv1.GetAggregateRatesRequest,
+) (*ratesv1.GetAggregateRatesResponse, error) {
+	return aggregate(ctx, req, s.service.GetMinRates)
+}
+
+func (s *server) GetAvgRates(
+	ctx context.Context,
+	req *ratesv1.GetAggregateRatesRequest,
+) (*ratesv1.GetAggregateRatesResponse, error) {
+	return aggregate(ctx, req, s.service.GetAvgRates)
+}
+
+func (s *server) ActualizeRates(
+	ctx context.Context,
+	_ *ratesv1.ActualizeRatesRequest,
+) (*ratesv1.ActualizeRatesResponse, error) {
+	err := s.actualize(ctx)
+	if errors.Is(err, scheduler.ErrAlreadyRunning) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ratesv1.ActualizeRatesResponse{}, nil
+}
+
+func aggregate(
+	ctx context.Context,
+	req *ratesv1.GetAggregateRatesRequest,
+	get func(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error),
+) (*ratesv1.GetAggregateRatesResponse, error) {
+	period, err := parsePeriod(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	coins, err := get(ctx, req.GetTitles(), req.GetQuote(), period)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ratesv1.GetAggregateRatesResponse{Rates: newRates(coins)}, nil
+}
+
+// parsePeriod accepts either from and to, each optional, or window, see
+// entities.ParsePeriod.
+func parsePeriod(req *ratesv1.GetAggregateRatesRequest, now time.Time) (entities.Period, error) {
+	var window string
+
+	if req.GetWindow() != nil {
+		if err := req.GetWindow().CheckValid(); err != nil {
+			return entities.Period{}, errors.Wrapf(entities.ErrInvalidParam, "invalid window: %v", err)
+		}
+
+		window = req.GetWindow().AsDuration().String()
+	}
+
+	from, err := formatBound("from", req.GetFrom())
+	if err != nil {
+		return entities.Period{}, err
+	}
+
+	to, err := formatBound("to", req.GetTo())
+	if err != nil {
+		return entities.Period{}, err
+	}
+
+	return entities.ParsePeriod(from, to, window, now)
+}
+
+// formatBound writes a period bound as RFC 3339, empty if not set.
+func formatBound(name string, value *timestamppb.Timestamp) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	if err := value.CheckValid(); err != nil {
+		return "", errors.Wrapf(entities.ErrInvalidParam, "invalid %s: %v", name, err)
+	}
+
+	return value.AsTime().Format(time.RFC3339Nano), nil
+}
+
+func newRates(coins []*entities.Coin) []*ratesv1.Rate {
+	rates := make([]*ratesv1.Rate, 0, len(coins))
+
+	for _, coin := range coins {
+		rates = append(rates, newRate(coin))
+	}
+
+	return rates
+}
+
+func newRate(coin *entities.Coin) *ratesv1.Rate {
+	return &ratesv1.Rate{
+		Title:    coin.Title,
+		Quote:    coin.Quote,
+		Cost:     coin.Cost.String(),
+		ActualAt: timestamppb.New(coin.ActualAt),
+	}
+}
+
+// unaryInterceptor bounds every call by the request timeout and converts its
+// error to a status. ActualizeRates is left to the bounds of s.actualize.
+func (s *server) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if info.FullMethod != ratesv1.RatesService_ActualizeRates_FullMethodName {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return resp, nil
+}
+
+func streamInterceptor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, stream); err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
+// toStatus converts a domain error to a status.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := entities.GRPCCode(err)
+
+	message := err.Error()
+
+	switch code {
+	case codes.Internal, codes.Unavailable, codes.DeadlineExceeded:
+		// Do not leak internals such as SQL errors to clients.
+		message = code.String()
+	}
+
+	info := &errdetails.ErrorInfo{Reason: string(entities.CodeOf(err)), Domain: errorDomain}
+
+	if titles := entities.TitlesOf(err); len(titles) > 0 {
+		info.Metadata = map[string]string{"titles": strings.Join(titles, ",")}
+	}
+
+	st, detailErr := status.New(code, message).WithDetails(info)
+	if detailErr != nil {
+		return status.Error(code, message)
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,253 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ratesv1 "crypto-project/api/rates/v1"
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/grpcapi"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+	"crypto-project/internal/scheduler"
+)
+
+func newTestClient(t *testing.T, service grpcapi.RatesService, cfg grpcapi.Config) ratesv1.RatesServiceClient {
+	t.Helper()
+
+	server, err := grpcapi.NewServer(service, cfg)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1 << 20)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return ratesv1.NewRatesServiceClient(conn)
+}
+
+// requireStatus checks the code and the ErrorInfo of a failed call.
+func requireStatus(t *testing.T, err error, code codes.Code, reason entities.Code, titles string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status: %v", err)
+	require.Equal(t, code, st.Code(), st.Message())
+
+	var info *errdetails.ErrorInfo
+
+	for _, detail := range st.Details() {
+		if detail, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = detail
+		}
+	}
+
+	require.NotNil(t, info)
+	require.Equal(t, string(reason), info.GetReason())
+	require.Equal(t, "crypto-project", info.GetDomain())
+	require.Equal(t, titles, info.GetMetadata()["titles"])
+}
+
+func TestNewServer(t *testing.T) {
+	t.Parallel()
+
+	_, err := grpcapi.NewServer(nil, grpcapi.Config{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestGetRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockCryptoProvider(ctrl)
+	storage := memory.NewStorage()
+
+	service, err := cases.NewService(provider, storage)
+	require.NoError(t, err)
+
+	client := newTestClient(t, service, grpcapi.Config{RequestTimeout: time.Second})
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: now.Add(-2 * time.Hour)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(120), ActualAt: now.Add(-30 * time.Minute)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(110), ActualAt: now.Add(-10 * time.Minute)},
+	}))
+
+	last, err := client.GetLastRates(ctx, &ratesv1.GetLastRatesRequest{Titles: []string{"BTC"}, Quote: "usd"})
+	require.NoError(t, err)
+	require.Len(t, last.GetRates(), 1)
+	require.Equal(t, "BTC", last.GetRates()[0].GetTitle())
+	require.Equal(t, "USD", last.GetRates()[0].GetQuote())
+	require.Equal(t, "110", last.GetRates()[0].GetCost())
+	require.Equal(t, now.Add(-10*time.Minute), last.GetRates()[0].GetActualAt().AsTime())
+
+	tests := []struct {
+		name string
+		call func(context.Context, *ratesv1.GetAggregateRatesRequest, ...grpc.CallOption) (*ratesv1.GetAggregateRatesResponse, error)
+		req  *ratesv1.GetAggregateRatesRequest
+		want string
+	}{
+		{
+			name: "max",
+			call: client.GetMaxRates,
+			req:  &ratesv1.GetAggregateRatesRequest{Titles: []string{"BTC"}},
+			want: "120",
+		},
+		{
+			name: "min within window",
+			call: client.GetMinRates,
+			req:  &ratesv1.GetAggregateRatesRequest{Titles: []string{"BTC"}, Window: durationpb.New(time.Hour)},
+			want: "110",
+		},
+		{
+			name: "avg from",
+			call: client.GetAvgRates,
+			req:  &ratesv1.GetAggregateRatesRequest{Titles: []string{"BTC"}, From: timestamppb.New(now.Add(-time.Hour))},
+			want: "115",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.call(ctx, tt.req)
+			require.NoError(t, err)
+			require.Len(t, resp.GetRates(), 1)
+			require.True(t, decimal.RequireFromString(tt.want).Equal(decimal.RequireFromString(resp.GetRates()[0].GetCost())),
+				"got %s", resp.GetRates()[0].GetCost())
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockCryptoProvider(ctrl)
+
+	provider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"NOPE"}, "USD").
+		Return(nil, nil)
+	provider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"DOWN"}, "USD").
+		Return(nil, entities.NewProviderError("coingecko", []string{"DOWN"}, true, errors.New("503 from upstream")))
+
+	service, err := cases.NewService(provider, memory.NewStorage())
+	require.NoError(t, err)
+
+	client := newTestClient(t, service, grpcapi.Config{})
+	ctx := context.Background()
+
+	_, err = client.GetLastRates(ctx, &ratesv1.GetLastRatesRequest{})
+	requireStatus(t, err, codes.InvalidArgument, entities.CodeInvalidParam, "")
+
+	_, err = client.GetLastRates(ctx, &ratesv1.GetLastRatesRequest{Titles: []string{"NOPE"}})
+	requireStatus(t, err, codes.NotFound, entities.CodeNotFound, "NOPE")
+
+	_, err = client.GetLastRates(ctx, &ratesv1.GetLastRatesRequest{Titles: []string{"DOWN"}})
+	requireStatus(t, err, codes.Unavailable, entities.CodeProvider, "DOWN")
+	// The cause is not leaked to clients.
+	require.NotContains(t, status.Convert(err).Message(), "upstream")
+
+	_, err = client.GetMaxRates(ctx, &ratesv1.GetAggregateRatesRequest{
+		Titles: []string{"BTC"},
+		From:   timestamppb.Now(),
+		Window: durationpb.New(time.Hour),
+	})
+	requireStatus(t, err, codes.InvalidArgument, entities.CodeInvalidParam, "")
+}
+
+func TestActualizeRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockCryptoProvider(ctrl)
+	storage := memory.NewStorage()
+
+	ctx := context.Background()
+	require.NoError(t, storage.Store(ctx, []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: time.Now().Add(-time.Hour)},
+	}))
+
+	provider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+		Return([]*entities.Coin{{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(130), ActualAt: time.Now()}}, nil)
+
+	service, err := cases.NewService(provider, storage)
+	require.NoError(t, err)
+
+	client := newTestClient(t, service, grpcapi.Config{})
+
+	_, err = client.ActualizeRates(ctx, &ratesv1.ActualizeRatesRequest{})
+	require.NoError(t, err)
+
+	coins, err := storage.GetActualCoin(ctx, []string{"BTC"}, "USD")
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, "130", coins[0].Cost.String())
+}
+
+func TestActualizeRatesOverlap(t *testing.T) {
+	t.Parallel()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), memory.NewStorage())
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	actualizer, err := scheduler.New(func(context.Context) error {
+		close(started)
+		<-release
+
+		return nil
+	}, scheduler.Config{Interval: time.Hour})
+	require.NoError(t, err)
+
+	client := newTestClient(t, service, grpcapi.Config{RequestTimeout: time.Second, Actualize: actualizer.RunNow})
+	ctx := context.Background()
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := client.ActualizeRates(ctx, &ratesv1.ActualizeRatesRequest{})
+		done <- err
+	}()
+
+	<-started
+
+	_, err = client.ActualizeRates(ctx, &ratesv1.ActualizeRatesRequest{})
+	require.Equal(t, codes.Aborted, status.Code(err), err)
+
+	close(release)
+	require.NoError(t, <-done)
+}
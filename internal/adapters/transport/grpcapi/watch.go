@@ -0,0 +1,128 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ratesv1 "crypto-project/api/rates/v1"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// watchBuffer bounds the events a stream may fall behind before it is ended.
+const watchBuffer = 64
+
+// WatchRates sends the stored rates following req.After, if set, then the
+// rates published to the feed. Subscribing before reading the history leaves
+// no gap between both; the cursor drops the live rates the history already had.
+func (s *server) WatchRates(req *ratesv1.WatchRatesRequest, stream ratesv1.RatesService_WatchRatesServer) error {
+	if s.feed == nil {
+		return status.Error(codes.Unimplemented, "rates feed not configured")
+	}
+
+	titles := req.GetTitles()
+	if len(titles) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty")
+	}
+
+	quote, err := entities.NormalizeQuote(req.GetQuote())
+	if err != nil {
+		return err
+	}
+
+	var cursor entities.RateCursor
+
+	if req.GetAfter() != "" {
+		if cursor, err = entities.ParseRateCursor(req.GetAfter()); err != nil {
+			return err
+		}
+	}
+
+	sub := s.feed.Subscribe(watchBuffer)
+	defer sub.Close()
+
+	if req.GetAfter() != "" {
+		if cursor, err = s.sendHistory(stream, titles, quote, cursor); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return subscriptionStatus(sub.Err())
+			}
+
+			if cursor, err = sendRates(stream, entities.FilterRates(event, titles, quote), cursor); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// sendHistory pages through the stored rates following cursor.
+func (s *server) sendHistory(
+	stream ratesv1.RatesService_WatchRatesServer,
+	titles []string,
+	quote string,
+	cursor entities.RateCursor,
+) (entities.RateCursor, error) {
+	for {
+		ctx, cancel := context.WithTimeout(stream.Context(), s.timeout)
+		coins, err := s.service.GetRatesAfter(ctx, titles, quote, cursor, cases.MaxRatesPage)
+		cancel()
+
+		if err != nil {
+			return cursor, err
+		}
+
+		if cursor, err = sendRates(stream, coins, cursor); err != nil {
+			return cursor, err
+		}
+
+		if len(coins) < cases.MaxRatesPage {
+			return cursor, nil
+		}
+	}
+}
+
+// sendRates sends the coins following cursor and returns the cursor of the
+// last one sent.
+func sendRates(
+	stream ratesv1.RatesService_WatchRatesServer,
+	coins []*entities.Coin,
+	cursor entities.RateCursor,
+) (entities.RateCursor, error) {
+	for _, coin := range coins {
+		next := entities.CursorOf(coin)
+		if next.Compare(cursor) <= 0 {
+			continue
+		}
+
+		if err := stream.Send(&ratesv1.WatchRatesResponse{Rate: newRate(coin), Cursor: next.String()}); err != nil {
+			return cursor, err
+		}
+
+		cursor = next
+	}
+
+	return cursor, nil
+}
+
+// subscriptionStatus tells the client why its subscription ended.
+func subscriptionStatus(err error) error {
+	switch {
+	case errors.Is(err, cases.ErrSlowConsumer):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, cases.ErrFeedClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Aborted, "subscription ended")
+	}
+}
@@ -0,0 +1,136 @@
+package grpcapi_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ratesv1 "crypto-project/api/rates/v1"
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/grpcapi"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestWatchRates(t *testing.T) {
+	t.Parallel()
+
+	storage := memory.NewStorage()
+	feed := cases.NewFeed()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), storage)
+	require.NoError(t, err)
+
+	client := newTestClient(t, service, grpcapi.Config{RequestTimeout: 50 * time.Millisecond, Feed: feed})
+
+	base := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	usd := func(title string, cost int64, minutes int) *entities.Coin {
+		return &entities.Coin{Title: title, Quote: "USD", Cost: decimal.NewFromInt(cost), ActualAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	history := []*entities.Coin{usd("BTC", 100, 0), usd("BTC", 110, 1), usd("ETH", 10, 1), usd("SOL", 1, 1)}
+	require.NoError(t, storage.Store(context.Background(), history))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stream, err := client.WatchRates(ctx, &ratesv1.WatchRatesRequest{
+		Titles: []string{"BTC", "ETH"},
+		After:  entities.CursorOf(history[0]).String(),
+	})
+	require.NoError(t, err)
+
+	for _, want := range []*entities.Coin{history[1], history[2]} {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, entities.CursorOf(want).String(), resp.GetCursor())
+		require.Equal(t, want.Cost.String(), resp.GetRate().GetCost())
+	}
+
+	// The live rates the history already had are not repeated, older rates
+	// stored since are not skipped, and the stream outlives RequestTimeout.
+	time.Sleep(100 * time.Millisecond)
+
+	live := []*entities.Coin{usd("BTC", 90, -5), usd("ETH", 11, 2), usd("SOL", 2, 2)}
+	require.NoError(t, storage.Store(context.Background(), live))
+	feed.Publish(context.Background(), entities.NewRatesEvent(append([]*entities.Coin{history[2]}, live...), time.Now()))
+
+	for _, want := range []*entities.Coin{live[0], live[1]} {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, entities.CursorOf(want).String(), resp.GetCursor())
+		require.Equal(t, want.Cost.String(), resp.GetRate().GetCost())
+	}
+
+	// Resuming from the history reads the older rate as well.
+	resumed, err := client.WatchRates(ctx, &ratesv1.WatchRatesRequest{
+		Titles: []string{"BTC", "ETH"},
+		After:  entities.CursorOf(history[2]).String(),
+	})
+	require.NoError(t, err)
+
+	for _, want := range []*entities.Coin{live[0], live[1]} {
+		resp, err := resumed.Recv()
+		require.NoError(t, err)
+		require.Equal(t, entities.CursorOf(want).String(), resp.GetCursor())
+		require.Equal(t, want.Cost.String(), resp.GetRate().GetCost())
+	}
+
+	feed.Close()
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestWatchRatesErrors(t *testing.T) {
+	t.Parallel()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), memory.NewStorage())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		feed grpcapi.RatesFeed
+		req  *ratesv1.WatchRatesRequest
+		want codes.Code
+	}{
+		{
+			name: "no titles",
+			feed: cases.NewFeed(),
+			req:  &ratesv1.WatchRatesRequest{},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "invalid cursor",
+			feed: cases.NewFeed(),
+			req:  &ratesv1.WatchRatesRequest{Titles: []string{"BTC"}, After: "yesterday"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "no feed",
+			req:  &ratesv1.WatchRatesRequest{Titles: []string{"BTC"}},
+			want: codes.Unimplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, service, grpcapi.Config{Feed: tt.feed})
+
+			stream, err := client.WatchRates(context.Background(), tt.req)
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			require.Equal(t, tt.want, status.Code(err), err)
+		})
+	}
+}
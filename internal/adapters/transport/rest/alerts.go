@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// AlertsService is the part of cases.Alerts the API exposes.
+type AlertsService interface {
+	CreateRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error)
+	GetRules(ctx context.Context) ([]*entities.AlertRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	GetEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error)
+}
+
+var _ AlertsService = (*cases.Alerts)(nil)
+
+// alertRuleRequest takes window and cooldown as durations such as 1h30m.
+type alertRuleRequest struct {
+	Title     string          `json:"title"`
+	Quote     string          `json:"quote"`
+	Condition string          `json:"condition"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Window    string          `json:"window"`
+	Cooldown  string          `json:"cooldown"`
+}
+
+type alertRuleResponse struct {
+	ID        int64           `json:"id"`
+	Title     string          `json:"title"`
+	Quote     string          `json:"quote"`
+	Condition string          `json:"condition"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Window    string          `json:"window,omitempty"`
+	Cooldown  string          `json:"cooldown"`
+	Firing    bool            `json:"firing"`
+	FiredAt   *time.Time      `json:"fired_at,omitempty"`
+}
+
+type alertRulesResponse struct {
+	Rules []alertRuleResponse `json:"rules"`
+}
+
+type alertEventResponse struct {
+	ID     int64           `json:"id"`
+	RuleID int64           `json:"rule_id"`
+	Title  string          `json:"title"`
+	Quote  string          `json:"quote"`
+	State  string          `json:"state"`
+	Cost   decimal.Decimal `json:"cost"`
+	Value  decimal.Decimal `json:"value"`
+	At     time.Time       `json:"at"`
+}
+
+type alertEventsResponse struct {
+	Events []alertEventResponse `json:"events"`
+}
+
+func (s *Server) handleCreateAlertRule(w http.ResponseWriter, r *http.Request) {
+	var req alertRuleRequest
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid body: %v", err))
+		return
+	}
+
+	window, err := parseOptionalDuration("window", req.Window)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cooldown, err := parseOptionalDuration("cooldown", req.Cooldown)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rule := entities.AlertRule{
+		Title:     req.Title,
+		Quote:     req.Quote,
+		Condition: entities.AlertCondition(req.Condition),
+		Threshold: req.Threshold,
+		Window:    window,
+		Cooldown:  cooldown,
+	}
+
+	created, err := s.alerts.CreateRule(r.Context(), rule)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newAlertRuleResponse(created))
+}
+
+func (s *Server) handleAlertRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := s.alerts.GetRules(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := alertRulesResponse{
+		Rules: make([]alertRuleResponse, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, newAlertRuleResponse(rule))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleDeleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid rule id %q", r.PathValue("id")))
+		return
+	}
+
+	if err = s.alerts.DeleteRule(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAlertEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var (
+		ruleID int64
+		limit  int
+		err    error
+	)
+
+	if value := query.Get("rule_id"); value != "" {
+		if ruleID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid rule_id %q", value))
+			return
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid limit %q", value))
+			return
+		}
+	}
+
+	events, err := s.alerts.GetEvents(r.Context(), ruleID, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := alertEventsResponse{
+		Events: make([]alertEventResponse, 0, len(events)),
+	}
+
+	for _, event := range events {
+		resp.Events = append(resp.Events, alertEventResponse{
+			ID:     event.ID,
+			RuleID: event.RuleID,
+			Title:  event.Title,
+			Quote:  event.Quote,
+			State:  string(event.State),
+			Cost:   event.Cost,
+			Value:  event.Value,
+			At:     event.At,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseOptionalDuration parses a duration such as 1h30m, empty meaning zero.
+func parseOptionalDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(entities.ErrInvalidParam, "invalid %s %q", name, value)
+	}
+
+	return d, nil
+}
+
+func newAlertRuleResponse(rule *entities.AlertRule) alertRuleResponse {
+	resp := alertRuleResponse{
+		ID:        rule.ID,
+		Title:     rule.Title,
+		Quote:     rule.Quote,
+		Condition: string(rule.Condition),
+		Threshold: rule.Threshold,
+		Cooldown:  rule.Cooldown.String(),
+		Firing:    rule.Firing,
+	}
+
+	if rule.Window > 0 {
+		resp.Window = rule.Window.String()
+	}
+
+	if !rule.FiredAt.IsZero() {
+		resp.FiredAt = &rule.FiredAt
+	}
+
+	return resp
+}
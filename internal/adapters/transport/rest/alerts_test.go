@@ -0,0 +1,118 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/rest"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	storage := memory.NewStorage()
+
+	alerts, err := cases.NewAlerts(storage, storage)
+	require.NoError(t, err)
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), storage)
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(service, rest.Config{Alerts: alerts})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	type rule struct {
+		ID        int64  `json:"id"`
+		Quote     string `json:"quote"`
+		Condition string `json:"condition"`
+		Threshold string `json:"threshold"`
+		Window    string `json:"window"`
+		Cooldown  string `json:"cooldown"`
+		Firing    bool   `json:"firing"`
+	}
+
+	var created rule
+
+	payload := `{"title": "BTC", "condition": "change", "threshold": "5", "window": "1h", "cooldown": "30m"}`
+	require.Equal(t, http.StatusCreated, post(t, httpServer.URL+"/v1/alerts/rules", payload, &created))
+	require.NotZero(t, created.ID)
+	require.Equal(t, entities.DefaultQuote, created.Quote)
+	require.Equal(t, "5", created.Threshold)
+	require.Equal(t, "1h0m0s", created.Window)
+	require.Equal(t, "30m0s", created.Cooldown)
+
+	var errResp errorResponse
+
+	payload = `{"title": "BTC", "condition": "change", "threshold": "5"}`
+	require.Equal(t, http.StatusBadRequest, post(t, httpServer.URL+"/v1/alerts/rules", payload, &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+
+	payload = `{"title": "BTC", "condition": "above", "threshold": "5", "cooldown": "soon"}`
+	require.Equal(t, http.StatusBadRequest, post(t, httpServer.URL+"/v1/alerts/rules", payload, &errResp))
+
+	now := time.Now()
+
+	require.NoError(t, storage.Store(context.Background(), []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: now.Add(-2 * time.Hour)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(110), ActualAt: now},
+	}))
+
+	_, err = alerts.Evaluate(context.Background())
+	require.NoError(t, err)
+
+	var rules struct {
+		Rules []rule `json:"rules"`
+	}
+
+	require.Equal(t, http.StatusOK, get(t, httpServer.URL+"/v1/alerts/rules", &rules))
+	require.Len(t, rules.Rules, 1)
+	require.True(t, rules.Rules[0].Firing)
+
+	var events struct {
+		Events []struct {
+			RuleID int64  `json:"rule_id"`
+			State  string `json:"state"`
+			Cost   string `json:"cost"`
+			Value  string `json:"value"`
+		} `json:"events"`
+	}
+
+	require.Equal(t, http.StatusOK, get(t, httpServer.URL+"/v1/alerts/events?rule_id="+strconv.FormatInt(created.ID, 10), &events))
+	require.Len(t, events.Events, 1)
+	require.Equal(t, created.ID, events.Events[0].RuleID)
+	require.Equal(t, "firing", events.Events[0].State)
+	require.Equal(t, "110", events.Events[0].Cost)
+	require.Equal(t, "10", events.Events[0].Value)
+
+	require.Equal(t, http.StatusBadRequest, get(t, httpServer.URL+"/v1/alerts/events?limit=-1", &errResp))
+
+	url := httpServer.URL + "/v1/alerts/rules/" + strconv.FormatInt(created.ID, 10)
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// handleRatesEvents streams the rates in quote of titles as Server-Sent Events:
+//
+//	id: 42
+//	event: rate
+//	data: {"title": "BTC", "quote": "USD", "cost": "42000.5", "actual_at": "2024-01-01T12:00:00Z"}
+//
+// The id is an entities.RateCursor. A client reconnecting with Last-Event-ID
+// first receives the stored rates it missed. Comments are sent every
+// heartbeat to keep idle connections open. Streams that fall behind are
+// closed, to be resumed the same way.
+func (s *Server) handleRatesEvents(w http.ResponseWriter, r *http.Request) {
+	titles, err := parseTitles(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	quote, err := entities.NormalizeQuote(r.URL.Query().Get("quote"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var cursor entities.RateCursor
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID != "" {
+		if cursor, err = entities.ParseRateCursor(lastEventID); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	// Subscribing before reading the history leaves no gap between both; the
+	// cursor drops the live rates the history already had.
+	sub := s.feed.Subscribe(streamBuffer)
+	defer sub.Close()
+
+	stream := &eventStream{w: w, rc: http.NewResponseController(w)}
+
+	// Streams escape the request timeout, every page of history still has it.
+	page := func(after entities.RateCursor) ([]*entities.Coin, error) {
+		ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+		defer cancel()
+
+		return s.service.GetRatesAfter(ctx, titles, quote, after, cases.MaxRatesPage)
+	}
+
+	if lastEventID != "" {
+		// The first page is read before answering, so that its errors are
+		// still reported with their status.
+		coins, err := page(cursor)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		for len(coins) > 0 {
+			if cursor, err = stream.rates(coins, cursor); err != nil {
+				return
+			}
+
+			if len(coins) < cases.MaxRatesPage {
+				break
+			}
+
+			if coins, err = page(cursor); err != nil {
+				return
+			}
+		}
+	}
+
+	if err = stream.start(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			if cursor, err = stream.rates(entities.FilterRates(event, titles, quote), cursor); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err = stream.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+type eventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	started bool
+}
+
+// start answers the request once, before the first message.
+func (e *eventStream) start() error {
+	if e.started {
+		return nil
+	}
+
+	e.started = true
+
+	e.w.Header().Set("Content-Type", "text/event-stream")
+	e.w.Header().Set("Cache-Control", "no-cache")
+	e.w.WriteHeader(http.StatusOK)
+
+	return e.rc.Flush()
+}
+
+// rates sends the coins following cursor and returns the cursor of the last
+// one sent.
+func (e *eventStream) rates(coins []*entities.Coin, cursor entities.RateCursor) (entities.RateCursor, error) {
+	for _, coin := range coins {
+		next := entities.CursorOf(coin)
+		if next.Compare(cursor) <= 0 {
+			continue
+		}
+
+		data, err := json.Marshal(coinResponse{Title: coin.Title, Quote: coin.Quote, Cost: coin.Cost, ActualAt: coin.ActualAt})
+		if err != nil {
+			return cursor, err
+		}
+
+		if err = e.write(fmt.Sprintf("id: %s\nevent: rate\ndata: %s\n\n", next, data)); err != nil {
+			return cursor, err
+		}
+
+		cursor = next
+	}
+
+	return cursor, nil
+}
+
+// write sends and flushes a message within streamWriteTimeout, so that a
+// stalled client cannot hold its connection.
+func (e *eventStream) write(message string) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	err := e.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	if _, err := e.w.Write([]byte(message)); err != nil {
+		return err
+	}
+
+	return e.rc.Flush()
+}
@@ -0,0 +1,161 @@
+package rest_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/rest"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  struct {
+		Title string `json:"title"`
+		Quote string `json:"quote"`
+		Cost  string `json:"cost"`
+	}
+}
+
+// readEvent skips comments and returns the next event.
+func readEvent(t *testing.T, scanner *bufio.Scanner) sseEvent {
+	t.Helper()
+
+	var event sseEvent
+
+	for scanner.Scan() {
+		field, value, _ := strings.Cut(scanner.Text(), ": ")
+
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			require.NoError(t, json.Unmarshal([]byte(value), &event.Data))
+		case "":
+			if event.ID != "" {
+				return event
+			}
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+	t.Fatal("stream ended")
+
+	return event
+}
+
+func TestRatesEvents(t *testing.T) {
+	t.Parallel()
+
+	storage := memory.NewStorage()
+	feed := cases.NewFeed()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), storage)
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(service, rest.Config{RequestTimeout: 50 * time.Millisecond, Feed: feed, Heartbeat: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	base := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	usd := func(title string, cost int64, minutes int) *entities.Coin {
+		return &entities.Coin{Title: title, Quote: "USD", Cost: decimal.NewFromInt(cost), ActualAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	history := []*entities.Coin{usd("BTC", 100, 0), usd("BTC", 110, 1), usd("ETH", 10, 1), usd("SOL", 1, 1)}
+	require.NoError(t, storage.Store(context.Background(), history))
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/v1/rates/events?titles=BTC,ETH", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", entities.CursorOf(history[0]).String())
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+
+	event := readEvent(t, scanner)
+	require.Equal(t, "rate", event.Event)
+	require.Equal(t, entities.CursorOf(history[1]).String(), event.ID)
+	require.Equal(t, "110", event.Data.Cost)
+
+	event = readEvent(t, scanner)
+	require.Equal(t, entities.CursorOf(history[2]).String(), event.ID)
+	require.Equal(t, "ETH", event.Data.Title)
+
+	// The live rates the history already had are not repeated, older rates
+	// stored since are not skipped, and the stream outlives RequestTimeout.
+	time.Sleep(100 * time.Millisecond)
+
+	live := []*entities.Coin{usd("BTC", 90, -5), usd("ETH", 11, 2), usd("SOL", 2, 2)}
+	require.NoError(t, storage.Store(context.Background(), live))
+	feed.Publish(context.Background(), entities.NewRatesEvent(append([]*entities.Coin{history[2]}, live...), time.Now()))
+
+	event = readEvent(t, scanner)
+	require.Equal(t, entities.CursorOf(live[0]).String(), event.ID)
+	require.Equal(t, "90", event.Data.Cost)
+
+	event = readEvent(t, scanner)
+	require.Equal(t, entities.CursorOf(live[1]).String(), event.ID)
+	require.Equal(t, "11", event.Data.Cost)
+
+	// Resuming from the history reads the older rate as well.
+	req.Header.Set("Last-Event-ID", entities.CursorOf(history[2]).String())
+
+	resumed, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resumed.Body.Close() })
+
+	event = readEvent(t, bufio.NewScanner(resumed.Body))
+	require.Equal(t, entities.CursorOf(live[0]).String(), event.ID)
+	require.Equal(t, "90", event.Data.Cost)
+}
+
+func TestRatesEventsErrors(t *testing.T) {
+	t.Parallel()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), memory.NewStorage())
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(service, rest.Config{Feed: cases.NewFeed()})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	var errResp errorResponse
+
+	require.Equal(t, http.StatusBadRequest, get(t, httpServer.URL+"/v1/rates/events", &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/v1/rates/events?titles=BTC", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "yesterday")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
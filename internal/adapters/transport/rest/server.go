@@ -0,0 +1,498 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const DefaultRequestTimeout = 15 * time.Second
+
+// maxBodyBytes bounds request bodies.
+const maxBodyBytes = 1 << 20
+
+// RatesService is the part of cases.Service the API exposes.
+type RatesService interface {
+	GetLastRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error)
+	GetMaxRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetMinRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetAvgRates(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+	GetCandles(
+		ctx context.Context,
+		titles []string,
+		quote string,
+		interval entities.Interval,
+		period entities.Period,
+	) ([]*entities.Candle, error)
+	Convert(
+		ctx context.Context,
+		from, to string,
+		amount decimal.Decimal,
+		maxAge time.Duration,
+	) (*entities.Conversion, error)
+	ValuePortfolio(ctx context.Context, holdings []entities.Holding, quote string) (*entities.Valuation, error)
+	GetRatesAfter(
+		ctx context.Context,
+		titles []string,
+		quote string,
+		after entities.RateCursor,
+		limit int,
+	) ([]*entities.Coin, error)
+}
+
+var _ RatesService = (*cases.Service)(nil)
+
+type Config struct {
+	// RequestTimeout bounds every request through its context.
+	RequestTimeout time.Duration
+	// Alerts, if set, enables the alert endpoints.
+	Alerts AlertsService
+	// Webhooks, if set, enables the webhook endpoints.
+	Webhooks WebhooksService
+	// Feed, if set, enables the streaming endpoints, which RequestTimeout does
+	// not apply to. Their connections are pinged every Heartbeat.
+	Feed      RatesFeed
+	Heartbeat time.Duration
+	// Metrics, if set, is served at GET /metrics.
+	Metrics http.Handler
+}
+
+// Server serves the rates API:
+//
+//	GET /v1/rates/last?titles=BTC,ETH
+//	GET /v1/rates/{agg}?titles=BTC,ETH where agg is max, min or avg
+//	GET /v1/candles?titles=BTC,ETH&interval=1h&window=24h where interval is 1m, 5m, 1h or 1d
+//	GET /v1/convert?from=ETH&to=SOL&amount=2.5
+//	POST /v1/portfolio/valuation with {"quote": "EUR", "holdings": [{"title": "BTC", "quantity": "0.5"}]}
+//
+// and, when configured with alerts:
+//
+//	GET /v1/alerts/rules
+//	POST /v1/alerts/rules with {"title": "BTC", "condition": "change", "threshold": "5", "window": "1h", "cooldown": "30m"}
+//	DELETE /v1/alerts/rules/{id}
+//	GET /v1/alerts/events?rule_id=1&limit=100
+//
+// and, when configured with webhooks:
+//
+//	GET /v1/webhooks
+//	POST /v1/webhooks with {"url": "https://example.com/hook", "secret": "s3cret", "events": ["alert.fired"]}
+//	DELETE /v1/webhooks/{id}
+//	GET /v1/webhooks/dead-letters?limit=100
+//	POST /v1/webhooks/dead-letters/{id}/replay
+//
+// and, when configured with a feed:
+//
+//	GET /v1/rates/stream?titles=BTC,ETH, a WebSocket of the rates as they are stored
+//	GET /v1/rates/events?titles=BTC,ETH, the same as Server-Sent Events resumable with Last-Event-ID
+//
+// and, when configured with metrics:
+//
+//	GET /metrics
+//
+// Every endpoint accepts quote, the currency to price titles in, which
+// defaults to entities.DefaultQuote. Aggregates cover the whole history unless
+// bounded with from and to (RFC 3339) or with window, a lookback duration such
+// as 24h. Candles need either window or from. Conversions accept max_age, the
+// oldest a rate may be such as 5m, and default to cases.DefaultMaxRateAge.
+type Server struct {
+	service  RatesService
+	alerts   AlertsService
+	webhooks WebhooksService
+	feed     RatesFeed
+	timeout  time.Duration
+	// heartbeat applies to the streams, which are served by streams rather
+	// than mux to escape timeout.
+	heartbeat time.Duration
+	mux       *http.ServeMux
+	streams   *http.ServeMux
+}
+
+func NewServer(service RatesService, cfg Config) (*Server, error) {
+	if service == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "service not set")
+	}
+
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = DefaultHeartbeat
+	}
+
+	s := &Server{
+		service:   service,
+		alerts:    cfg.Alerts,
+		webhooks:  cfg.Webhooks,
+		feed:      cfg.Feed,
+		timeout:   cfg.RequestTimeout,
+		heartbeat: cfg.Heartbeat,
+		mux:       http.NewServeMux(),
+		streams:   http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /v1/rates/last", s.handleLastRates)
+	s.mux.HandleFunc("GET /v1/rates/{agg}", s.handleAggregateRates)
+	s.mux.HandleFunc("GET /v1/candles", s.handleCandles)
+	s.mux.HandleFunc("GET /v1/convert", s.handleConvert)
+	s.mux.HandleFunc("POST /v1/portfolio/valuation", s.handleValuePortfolio)
+
+	if s.alerts != nil {
+		s.mux.HandleFunc("GET /v1/alerts/rules", s.handleAlertRules)
+		s.mux.HandleFunc("POST /v1/alerts/rules", s.handleCreateAlertRule)
+		s.mux.HandleFunc("DELETE /v1/alerts/rules/{id}", s.handleDeleteAlertRule)
+		s.mux.HandleFunc("GET /v1/alerts/events", s.handleAlertEvents)
+	}
+
+	if s.webhooks != nil {
+		s.mux.HandleFunc("GET /v1/webhooks", s.handleWebhooks)
+		s.mux.HandleFunc("POST /v1/webhooks", s.handleCreateWebhook)
+		s.mux.HandleFunc("DELETE /v1/webhooks/{id}", s.handleDeleteWebhook)
+		s.mux.HandleFunc("GET /v1/webhooks/dead-letters", s.handleDeadLetters)
+		s.mux.HandleFunc("POST /v1/webhooks/dead-letters/{id}/replay", s.handleReplayDeadLetter)
+	}
+
+	if cfg.Metrics != nil {
+		s.mux.Handle("GET /metrics", cfg.Metrics)
+	}
+
+	if s.feed != nil {
+		s.streams.HandleFunc("GET /v1/rates/stream", s.handleRatesSocket)
+		s.streams.HandleFunc("GET /v1/rates/events", s.handleRatesEvents)
+	}
+
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if stream, pattern := s.streams.Handler(r); pattern != "" {
+		stream.ServeHTTP(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	s.mux.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type coinResponse struct {
+	Title    string          `json:"title"`
+	Quote    string          `json:"quote"`
+	Cost     decimal.Decimal `json:"cost"`
+	ActualAt time.Time       `json:"actual_at"`
+}
+
+type ratesResponse struct {
+	Rates []coinResponse `json:"rates"`
+}
+
+type candleResponse struct {
+	Title    string          `json:"title"`
+	Quote    string          `json:"quote"`
+	Interval string          `json:"interval"`
+	Start    time.Time       `json:"start"`
+	Open     decimal.Decimal `json:"open"`
+	High     decimal.Decimal `json:"high"`
+	Low      decimal.Decimal `json:"low"`
+	Close    decimal.Decimal `json:"close"`
+}
+
+type candlesResponse struct {
+	Candles []candleResponse `json:"candles"`
+}
+
+type conversionResponse struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Amount   decimal.Decimal `json:"amount"`
+	Result   decimal.Decimal `json:"result"`
+	Rate     decimal.Decimal `json:"rate"`
+	Rates    []coinResponse  `json:"rates"`
+	ActualAt time.Time       `json:"actual_at"`
+}
+
+type valuationRequest struct {
+	Quote    string `json:"quote"`
+	Holdings []struct {
+		Title    string          `json:"title"`
+		Quantity decimal.Decimal `json:"quantity"`
+	} `json:"holdings"`
+}
+
+type valuationResponse struct {
+	Quote     string           `json:"quote"`
+	Total     decimal.Decimal  `json:"total"`
+	Change24h *decimal.Decimal `json:"change_24h"`
+	Assets    []assetResponse  `json:"assets"`
+}
+
+type assetResponse struct {
+	Title     string           `json:"title"`
+	Quantity  decimal.Decimal  `json:"quantity"`
+	Cost      decimal.Decimal  `json:"cost"`
+	Value     decimal.Decimal  `json:"value"`
+	Weight    decimal.Decimal  `json:"weight"`
+	Change24h *decimal.Decimal `json:"change_24h"`
+	ActualAt  time.Time        `json:"actual_at"`
+}
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    entities.Code `json:"code"`
+	Message string        `json:"message"`
+	Titles  []string      `json:"titles,omitempty"`
+}
+
+func (s *Server) handleLastRates(w http.ResponseWriter, r *http.Request) {
+	titles, err := parseTitles(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	coins, err := s.service.GetLastRates(r.Context(), titles, r.URL.Query().Get("quote"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRatesResponse(coins))
+}
+
+func (s *Server) handleAggregateRates(w http.ResponseWriter, r *http.Request) {
+	var get func(ctx context.Context, titles []string, quote string, period entities.Period) ([]*entities.Coin, error)
+
+	switch agg := r.PathValue("agg"); agg {
+	case cases.AggTypeMax:
+		get = s.service.GetMaxRates
+	case cases.AggTypeMin:
+		get = s.service.GetMinRates
+	case cases.AggTypeAvg:
+		get = s.service.GetAvgRates
+	default:
+		writeError(w, entities.NewNotFoundError(nil))
+		return
+	}
+
+	titles, err := parseTitles(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	period, err := parsePeriod(r, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	coins, err := get(r.Context(), titles, r.URL.Query().Get("quote"), period)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRatesResponse(coins))
+}
+
+func (s *Server) handleCandles(w http.ResponseWriter, r *http.Request) {
+	titles, err := parseTitles(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	interval, err := entities.ParseInterval(r.URL.Query().Get("interval"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	period, err := parsePeriod(r, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	candles, err := s.service.GetCandles(r.Context(), titles, r.URL.Query().Get("quote"), interval, period)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := candlesResponse{
+		Candles: make([]candleResponse, 0, len(candles)),
+	}
+
+	for _, candle := range candles {
+		resp.Candles = append(resp.Candles, candleResponse{
+			Title:    candle.Title,
+			Quote:    candle.Quote,
+			Interval: candle.Interval.String(),
+			Start:    candle.Start,
+			Open:     candle.Open,
+			High:     candle.High,
+			Low:      candle.Low,
+			Close:    candle.Close,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	amount, err := decimal.NewFromString(query.Get("amount"))
+	if err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid amount %q", query.Get("amount")))
+		return
+	}
+
+	var maxAge time.Duration
+
+	if value := query.Get("max_age"); value != "" {
+		if maxAge, err = time.ParseDuration(value); err != nil {
+			writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid max_age %q", value))
+			return
+		}
+	}
+
+	conversion, err := s.service.Convert(r.Context(), query.Get("from"), query.Get("to"), amount, maxAge)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, conversionResponse{
+		From:     conversion.From,
+		To:       conversion.To,
+		Amount:   conversion.Amount,
+		Result:   conversion.Result,
+		Rate:     conversion.Rate,
+		Rates:    newRatesResponse(conversion.Legs).Rates,
+		ActualAt: conversion.ActualAt,
+	})
+}
+
+func (s *Server) handleValuePortfolio(w http.ResponseWriter, r *http.Request) {
+	var req valuationRequest
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid body: %v", err))
+		return
+	}
+
+	holdings := make([]entities.Holding, 0, len(req.Holdings))
+
+	for _, holding := range req.Holdings {
+		holdings = append(holdings, entities.Holding{Title: holding.Title, Quantity: holding.Quantity})
+	}
+
+	valuation, err := s.service.ValuePortfolio(r.Context(), holdings, req.Quote)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := valuationResponse{
+		Quote:     valuation.Quote,
+		Total:     valuation.Total,
+		Change24h: valuation.Change24h,
+		Assets:    make([]assetResponse, 0, len(valuation.Assets)),
+	}
+
+	for _, asset := range valuation.Assets {
+		resp.Assets = append(resp.Assets, assetResponse{
+			Title:     asset.Title,
+			Quantity:  asset.Quantity,
+			Cost:      asset.Cost,
+			Value:     asset.Value,
+			Weight:    asset.Weight,
+			Change24h: asset.Change24h,
+			ActualAt:  asset.ActualAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseTitles accepts both titles=BTC,ETH and titles=BTC&titles=ETH.
+func parseTitles(r *http.Request) ([]string, error) {
+	titles := make([]string, 0)
+
+	for _, value := range r.URL.Query()["titles"] {
+		for _, title := range strings.Split(value, ",") {
+			if title = strings.TrimSpace(title); title != "" {
+				titles = append(titles, title)
+			}
+		}
+	}
+
+	if len(titles) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "titles query parameter is required")
+	}
+
+	return titles, nil
+}
+
+// parsePeriod reads the from, to and window query parameters, see
+// entities.ParsePeriod.
+func parsePeriod(r *http.Request, now time.Time) (entities.Period, error) {
+	query := r.URL.Query()
+
+	return entities.ParsePeriod(query.Get("from"), query.Get("to"), query.Get("window"), now)
+}
+
+func newRatesResponse(coins []*entities.Coin) ratesResponse {
+	resp := ratesResponse{
+		Rates: make([]coinResponse, 0, len(coins)),
+	}
+
+	for _, coin := range coins {
+		resp.Rates = append(resp.Rates, coinResponse{
+			Title:    coin.Title,
+			Quote:    coin.Quote,
+			Cost:     coin.Cost,
+			ActualAt: coin.ActualAt,
+		})
+	}
+
+	return resp
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := entities.HTTPStatus(err)
+
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		// Do not leak internals such as SQL errors to clients.
+		message = http.StatusText(status)
+	}
+
+	writeJSON(w, status, errorResponse{
+		Error: errorBody{
+			Code:    entities.CodeOf(err),
+			Message: message,
+			Titles:  entities.TitlesOf(err),
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
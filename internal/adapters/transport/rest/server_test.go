@@ -0,0 +1,407 @@
+package rest_test
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/rest"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+type errorResponse struct {
+	Error struct {
+		Code    string   `json:"code"`
+		Message string   `json:"message"`
+		Titles  []string `json:"titles"`
+	} `json:"error"`
+}
+
+type ratesResponse struct {
+	Rates []struct {
+		Title    string    `json:"title"`
+		Quote    string    `json:"quote"`
+		Cost     string    `json:"cost"`
+		ActualAt time.Time `json:"actual_at"`
+	} `json:"rates"`
+}
+
+func newTestServer(t *testing.T, provider cases.CryptoProvider) (*httptest.Server, *memory.Storage) {
+	t.Helper()
+
+	storage := memory.NewStorage()
+
+	service, err := cases.NewService(provider, storage)
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(service, rest.Config{RequestTimeout: time.Second})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	return httpServer, storage
+}
+
+func get(t *testing.T, url string, body any) int {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(body))
+
+	return resp.StatusCode
+}
+
+func post(t *testing.T, url, payload string, body any) int {
+	t.Helper()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(body))
+
+	return resp.StatusCode
+}
+
+func TestRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	server, storage := newTestServer(t, mocks.NewMockCryptoProvider(ctrl))
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, storage.Store(context.Background(), []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: now.Add(-time.Minute)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(300), ActualAt: now},
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(10), ActualAt: now},
+		{Title: "BTC", Quote: "EUR", Cost: decimal.NewFromInt(270), ActualAt: now},
+	}))
+
+	testTable := []struct {
+		path          string
+		expected      map[string]string
+		expectedQuote string
+	}{
+		{path: "/v1/rates/last?titles=BTC,ETH", expected: map[string]string{"BTC": "300", "ETH": "10"}},
+		{path: "/v1/rates/max?titles=BTC&titles=ETH", expected: map[string]string{"BTC": "300", "ETH": "10"}},
+		{path: "/v1/rates/min?titles=BTC", expected: map[string]string{"BTC": "100"}},
+		{path: "/v1/rates/avg?titles=+BTC+,", expected: map[string]string{"BTC": "200"}},
+		{path: "/v1/rates/min?titles=BTC&window=30s", expected: map[string]string{"BTC": "300"}},
+		{
+			path:     "/v1/rates/avg?titles=BTC,ETH&from=" + now.Add(-time.Second).Format(time.RFC3339),
+			expected: map[string]string{"BTC": "300", "ETH": "10"},
+		},
+		{path: "/v1/rates/last?titles=BTC&quote=eur", expected: map[string]string{"BTC": "270"}, expectedQuote: "EUR"},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.path, func(t *testing.T) {
+			var resp ratesResponse
+
+			require.Equal(t, http.StatusOK, get(t, server.URL+tc.path, &resp))
+			require.Len(t, resp.Rates, len(tc.expected))
+
+			for _, rate := range resp.Rates {
+				require.Equal(t, tc.expected[rate.Title], rate.Cost, rate.Title)
+				require.Equal(t, cmp.Or(tc.expectedQuote, entities.DefaultQuote), rate.Quote, rate.Title)
+				require.True(t, now.Equal(rate.ActualAt), rate.Title)
+			}
+		})
+	}
+}
+
+func TestCandles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	server, storage := newTestServer(t, mocks.NewMockCryptoProvider(ctrl))
+
+	start := entities.Interval1h.Start(time.Now()).Add(-time.Hour)
+
+	require.NoError(t, storage.Store(context.Background(), []*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: start},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(300), ActualAt: start.Add(10 * time.Minute)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(50), ActualAt: start.Add(20 * time.Minute)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(200), ActualAt: start.Add(30 * time.Minute)},
+	}))
+
+	var resp struct {
+		Candles []struct {
+			Title    string    `json:"title"`
+			Interval string    `json:"interval"`
+			Start    time.Time `json:"start"`
+			Open     string    `json:"open"`
+			High     string    `json:"high"`
+			Low      string    `json:"low"`
+			Close    string    `json:"close"`
+		} `json:"candles"`
+	}
+
+	require.Equal(t, http.StatusOK, get(t, server.URL+"/v1/candles?titles=BTC&interval=1h&window=3h", &resp))
+	require.Len(t, resp.Candles, 1)
+
+	candle := resp.Candles[0]
+	require.Equal(t, "BTC", candle.Title)
+	require.Equal(t, "1h", candle.Interval)
+	require.True(t, start.Equal(candle.Start))
+	require.Equal(t, []string{"100", "300", "50", "200"}, []string{candle.Open, candle.High, candle.Low, candle.Close})
+
+	var errResp errorResponse
+
+	require.Equal(t, http.StatusBadRequest, get(t, server.URL+"/v1/candles?titles=BTC&interval=2h&window=3h", &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+
+	require.Equal(t, http.StatusBadRequest, get(t, server.URL+"/v1/candles?titles=BTC&interval=1h", &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	server, storage := newTestServer(t, mocks.NewMockCryptoProvider(ctrl))
+
+	now := time.Now()
+
+	require.NoError(t, storage.Store(context.Background(), []*entities.Coin{
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(3000), ActualAt: now},
+		{Title: "SOL", Quote: "USD", Cost: decimal.NewFromInt(150), ActualAt: now.Add(-time.Minute)},
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(60000), ActualAt: now.Add(-time.Hour)},
+	}))
+
+	var resp struct {
+		ratesResponse
+		From     string    `json:"from"`
+		To       string    `json:"to"`
+		Amount   string    `json:"amount"`
+		Result   string    `json:"result"`
+		Rate     string    `json:"rate"`
+		ActualAt time.Time `json:"actual_at"`
+	}
+
+	require.Equal(t, http.StatusOK, get(t, server.URL+"/v1/convert?from=ETH&to=SOL&amount=2.5", &resp))
+	require.Equal(t, "ETH", resp.From)
+	require.Equal(t, "SOL", resp.To)
+	require.Equal(t, "2.5", resp.Amount)
+	require.Equal(t, "50", resp.Result)
+	require.Equal(t, "20", resp.Rate)
+	require.Len(t, resp.Rates, 2)
+	require.Equal(t, "3000", resp.Rates[0].Cost)
+	require.Equal(t, "150", resp.Rates[1].Cost)
+	require.True(t, now.Add(-time.Minute).Equal(resp.ActualAt))
+
+	var errResp errorResponse
+
+	require.Equal(t, http.StatusUnprocessableEntity, get(t, server.URL+"/v1/convert?from=BTC&to=SOL&amount=1", &errResp))
+	require.Equal(t, string(entities.CodeStaleRate), errResp.Error.Code)
+	require.Equal(t, []string{"BTC"}, errResp.Error.Titles)
+
+	require.Equal(t, http.StatusOK, get(t, server.URL+"/v1/convert?from=BTC&to=SOL&amount=1&max_age=2h", &resp))
+	require.Equal(t, "400", resp.Result)
+
+	require.Equal(t, http.StatusBadRequest, get(t, server.URL+"/v1/convert?from=ETH&to=SOL&amount=lots", &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+
+	require.Equal(t, http.StatusNotFound, get(t, server.URL+"/v1/convert?from=ETH&to=DOGE&amount=1", &errResp))
+	require.Equal(t, string(entities.CodeNotFound), errResp.Error.Code)
+}
+
+func TestValuePortfolio(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	server, storage := newTestServer(t, mocks.NewMockCryptoProvider(ctrl))
+
+	now := time.Now()
+
+	require.NoError(t, storage.Store(context.Background(), []*entities.Coin{
+		{Title: "BTC", Quote: "EUR", Cost: decimal.NewFromInt(40000), ActualAt: now.Add(-cases.ChangeWindow - time.Minute)},
+		{Title: "BTC", Quote: "EUR", Cost: decimal.NewFromInt(50000), ActualAt: now},
+		{Title: "ETH", Quote: "EUR", Cost: decimal.NewFromInt(2500), ActualAt: now},
+	}))
+
+	var resp struct {
+		Quote     string  `json:"quote"`
+		Total     string  `json:"total"`
+		Change24h *string `json:"change_24h"`
+		Assets    []struct {
+			Title     string  `json:"title"`
+			Value     string  `json:"value"`
+			Weight    string  `json:"weight"`
+			Change24h *string `json:"change_24h"`
+		} `json:"assets"`
+	}
+
+	payload := `{"quote": "eur", "holdings": [{"title": "BTC", "quantity": "0.5"}, {"title": "ETH", "quantity": 10}]}`
+
+	require.Equal(t, http.StatusOK, post(t, server.URL+"/v1/portfolio/valuation", payload, &resp))
+	require.Equal(t, "EUR", resp.Quote)
+	require.Equal(t, "50000", resp.Total)
+	require.Nil(t, resp.Change24h)
+	require.Len(t, resp.Assets, 2)
+	require.Equal(t, "BTC", resp.Assets[0].Title)
+	require.Equal(t, "25000", resp.Assets[0].Value)
+	require.Equal(t, "0.5", resp.Assets[0].Weight)
+	require.Equal(t, "0.25", *resp.Assets[0].Change24h)
+	require.Equal(t, "ETH", resp.Assets[1].Title)
+	require.Nil(t, resp.Assets[1].Change24h)
+
+	var errResp errorResponse
+
+	require.Equal(t, http.StatusBadRequest, post(t, server.URL+"/v1/portfolio/valuation", `{"holdings": "BTC"}`, &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+
+	require.Equal(t, http.StatusBadRequest, post(t, server.URL+"/v1/portfolio/valuation", `{"holdings": []}`, &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockCryptoProvider(ctrl)
+	server, _ := newTestServer(t, provider)
+
+	provider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"NOPE"}, "USD").
+		Return([]*entities.Coin{}, nil)
+	provider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+		Return(nil, entities.NewProviderError("coingecko", []string{"BTC"}, true, context.DeadlineExceeded))
+
+	testTable := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedCode   string
+		expectedTitles []string
+	}{
+		{
+			name:           "missing titles",
+			path:           "/v1/rates/last",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   string(entities.CodeInvalidParam),
+		},
+		{
+			name:           "unknown aggregate",
+			path:           "/v1/rates/median?titles=BTC",
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   string(entities.CodeNotFound),
+		},
+		{
+			name:           "invalid window",
+			path:           "/v1/rates/max?titles=BTC&window=day",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   string(entities.CodeInvalidParam),
+		},
+		{
+			name:           "window with bounds",
+			path:           "/v1/rates/max?titles=BTC&window=1h&to=2024-01-01T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   string(entities.CodeInvalidParam),
+		},
+		{
+			name:           "inverted period",
+			path:           "/v1/rates/avg?titles=BTC&from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   string(entities.CodeInvalidParam),
+		},
+		{
+			name:           "unknown coin",
+			path:           "/v1/rates/last?titles=NOPE",
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   string(entities.CodeNotFound),
+			expectedTitles: []string{"NOPE"},
+		},
+		{
+			name:           "provider timeout",
+			path:           "/v1/rates/max?titles=BTC",
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   string(entities.CodeProvider),
+			expectedTitles: []string{"BTC"},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp errorResponse
+
+			require.Equal(t, tc.expectedStatus, get(t, server.URL+tc.path, &resp))
+			require.Equal(t, tc.expectedCode, resp.Error.Code)
+			require.Equal(t, tc.expectedTitles, resp.Error.Titles)
+			require.NotEmpty(t, resp.Error.Message)
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockCryptoProvider(ctrl)
+	server, _ := newTestServer(t, provider)
+
+	provider.EXPECT().
+		GetActualRates(gomock.Any(), []string{"BTC"}, "USD").
+		DoAndReturn(func(ctx context.Context, titles []string, _ string) ([]*entities.Coin, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok, "request timeout must reach the provider")
+			require.WithinDuration(t, time.Now().Add(time.Second), deadline, time.Second)
+
+			<-ctx.Done()
+
+			return nil, entities.NewProviderError("test", titles, false, ctx.Err())
+		})
+
+	var resp errorResponse
+
+	require.Equal(t, http.StatusGatewayTimeout, get(t, server.URL+"/v1/rates/last?titles=BTC", &resp))
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), memory.NewStorage())
+	require.NoError(t, err)
+
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("crypto_up 1\n"))
+	})
+
+	for _, tt := range []struct {
+		name    string
+		metrics http.Handler
+		want    int
+	}{
+		{name: "served", metrics: metrics, want: http.StatusOK},
+		{name: "not configured", want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := rest.NewServer(service, rest.Config{Metrics: tt.metrics})
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			require.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
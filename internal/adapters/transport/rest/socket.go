@@ -0,0 +1,231 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultHeartbeat = 30 * time.Second
+
+	// streamBuffer bounds the events a stream may fall behind before it is
+	// dropped as a slow consumer.
+	streamBuffer = 64
+	// streamWriteTimeout bounds every write to a stream, so that a stalled
+	// client cannot hold its connection.
+	streamWriteTimeout = 10 * time.Second
+	maxSocketMessage   = 4 << 10
+)
+
+// RatesFeed is the part of cases.Feed the streaming endpoints use.
+type RatesFeed interface {
+	Subscribe(buffer int) *cases.Subscription
+}
+
+var _ RatesFeed = (*cases.Feed)(nil)
+
+// socketRequest is a client message:
+//
+//	{"type": "subscribe", "titles": ["BTC", "ETH"]}
+//	{"type": "unsubscribe", "titles": ["ETH"]}
+type socketRequest struct {
+	Type   string   `json:"type"`
+	Titles []string `json:"titles"`
+}
+
+// socketMessage is a server message: "subscribed" with every title of the
+// connection after each request, "rate" with a stored rate, or "error".
+type socketMessage struct {
+	Type   string        `json:"type"`
+	Titles []string      `json:"titles,omitempty"`
+	Rate   *coinResponse `json:"rate,omitempty"`
+	Error  *errorBody    `json:"error,omitempty"`
+}
+
+var upgrader = websocket.Upgrader{}
+
+// handleRatesSocket streams the rates in quote of the subscribed titles as
+// they are stored. The titles query parameter, if any, is the initial
+// subscription. Connections are pinged every heartbeat and closed when they
+// stop answering or fall behind.
+func (s *Server) handleRatesSocket(w http.ResponseWriter, r *http.Request) {
+	quote, err := entities.NormalizeQuote(r.URL.Query().Get("quote"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	titles := make(map[string]struct{})
+
+	if r.URL.Query().Has("titles") {
+		initial, err := parseTitles(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		for _, title := range initial {
+			titles[title] = struct{}{}
+		}
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has answered the client already.
+		return
+	}
+	defer conn.Close()
+
+	sub := s.feed.Subscribe(streamBuffer)
+	defer sub.Close()
+
+	requests := make(chan socketRequest)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		readErr <- s.readSocket(conn, requests, done)
+	}()
+
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	if len(titles) > 0 {
+		err = writeSocket(conn, socketMessage{Type: "subscribed", Titles: sortedTitles(titles)})
+	}
+
+	for err == nil {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				closeSocket(conn, sub.Err())
+				return
+			}
+
+			err = writeRates(conn, event, titles, quote)
+		case req := <-requests:
+			err = writeSocket(conn, handleSocketRequest(req, titles))
+		case <-heartbeat.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout))
+		case <-readErr:
+			return
+		}
+	}
+}
+
+// readSocket passes the client requests on until the connection fails or done
+// is closed. Every pong extends the read deadline by two heartbeats.
+func (s *Server) readSocket(conn *websocket.Conn, requests chan<- socketRequest, done <-chan struct{}) error {
+	conn.SetReadLimit(maxSocketMessage)
+
+	deadline := func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * s.heartbeat))
+	}
+
+	_ = deadline("")
+	conn.SetPongHandler(deadline)
+
+	for {
+		var req socketRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return err
+		}
+
+		select {
+		case requests <- req:
+		case <-done:
+			return nil
+		}
+	}
+}
+
+func handleSocketRequest(req socketRequest, titles map[string]struct{}) socketMessage {
+	if len(req.Titles) == 0 {
+		return socketError(errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty"))
+	}
+
+	switch req.Type {
+	case "subscribe":
+		for _, title := range req.Titles {
+			titles[title] = struct{}{}
+		}
+	case "unsubscribe":
+		for _, title := range req.Titles {
+			delete(titles, title)
+		}
+	default:
+		return socketError(errors.Wrapf(entities.ErrInvalidParam, "unknown request type %q", req.Type))
+	}
+
+	return socketMessage{Type: "subscribed", Titles: sortedTitles(titles)}
+}
+
+func writeRates(conn *websocket.Conn, event *entities.Event, titles map[string]struct{}, quote string) error {
+	for _, coin := range event.Rates {
+		if _, ok := titles[coin.Title]; !ok || coin.Quote != quote {
+			continue
+		}
+
+		rate := coinResponse{Title: coin.Title, Quote: coin.Quote, Cost: coin.Cost, ActualAt: coin.ActualAt}
+
+		if err := writeSocket(conn, socketMessage{Type: "rate", Rate: &rate}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeSocket(conn *websocket.Conn, msg socketMessage) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+		return err
+	}
+
+	return conn.WriteJSON(msg)
+}
+
+// closeSocket tells the client why its subscription ended.
+func closeSocket(conn *websocket.Conn, reason error) {
+	code := websocket.CloseNormalClosure
+
+	switch {
+	case errors.Is(reason, cases.ErrSlowConsumer):
+		code = websocket.CloseTryAgainLater
+	case errors.Is(reason, cases.ErrFeedClosed):
+		code = websocket.CloseGoingAway
+	}
+
+	text := ""
+	if reason != nil {
+		text = reason.Error()
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(streamWriteTimeout))
+}
+
+func socketError(err error) socketMessage {
+	return socketMessage{
+		Type:  "error",
+		Error: &errorBody{Code: entities.CodeOf(err), Message: err.Error()},
+	}
+}
+
+func sortedTitles(titles map[string]struct{}) []string {
+	sorted := make([]string, 0, len(titles))
+
+	for title := range titles {
+		sorted = append(sorted, title)
+	}
+
+	slices.Sort(sorted)
+
+	return sorted
+}
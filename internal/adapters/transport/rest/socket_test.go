@@ -0,0 +1,153 @@
+package rest_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/rest"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+type socketMessage struct {
+	Type   string   `json:"type"`
+	Titles []string `json:"titles"`
+	Rate   *struct {
+		Title string `json:"title"`
+		Quote string `json:"quote"`
+		Cost  string `json:"cost"`
+	} `json:"rate"`
+	Error *struct {
+		Code string `json:"code"`
+	} `json:"error"`
+}
+
+func dialRates(t *testing.T, feed *cases.Feed, heartbeat time.Duration, query string) *websocket.Conn {
+	t.Helper()
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), memory.NewStorage())
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(service, rest.Config{
+		RequestTimeout: 50 * time.Millisecond,
+		Feed:           feed,
+		Heartbeat:      heartbeat,
+	})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/v1/rates/stream" + query
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) socketMessage {
+	t.Helper()
+
+	var msg socketMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	return msg
+}
+
+func TestRatesSocket(t *testing.T) {
+	t.Parallel()
+
+	feed := cases.NewFeed()
+	conn := dialRates(t, feed, time.Minute, "?titles=BTC")
+
+	require.Equal(t, socketMessage{Type: "subscribed", Titles: []string{"BTC"}}, readMessage(t, conn))
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "subscribe", "titles": []string{"ETH"}}))
+	require.Equal(t, socketMessage{Type: "subscribed", Titles: []string{"BTC", "ETH"}}, readMessage(t, conn))
+
+	now := time.Now()
+	feed.Publish(context.Background(), entities.NewRatesEvent([]*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: now},
+		{Title: "BTC", Quote: "EUR", Cost: decimal.NewFromInt(90), ActualAt: now},
+		{Title: "SOL", Quote: "USD", Cost: decimal.NewFromInt(5), ActualAt: now},
+		{Title: "ETH", Quote: "USD", Cost: decimal.NewFromInt(10), ActualAt: now},
+	}, now))
+
+	for _, expected := range []string{"BTC", "ETH"} {
+		msg := readMessage(t, conn)
+		require.Equal(t, "rate", msg.Type)
+		require.Equal(t, expected, msg.Rate.Title)
+		require.Equal(t, "USD", msg.Rate.Quote)
+	}
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "unsubscribe", "titles": []string{"BTC", "ETH"}}))
+	require.Equal(t, socketMessage{Type: "subscribed"}, readMessage(t, conn))
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "resubscribe", "titles": []string{"BTC"}}))
+	msg := readMessage(t, conn)
+	require.Equal(t, "error", msg.Type)
+	require.Equal(t, string(entities.CodeInvalidParam), msg.Error.Code)
+
+	// The stream outlives RequestTimeout.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "subscribe", "titles": []string{"SOL"}}))
+	require.Equal(t, []string{"SOL"}, readMessage(t, conn).Titles)
+}
+
+func TestRatesSocketHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	conn := dialRates(t, cases.NewFeed(), 20*time.Millisecond, "")
+
+	pings := make(chan struct{}, 1)
+	conn.SetPingHandler(func(string) error {
+		select {
+		case pings <- struct{}{}:
+		default:
+		}
+
+		return conn.WriteControl(websocket.PongMessage, nil, time.Now().Add(time.Second))
+	})
+
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pings:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no heartbeat")
+	}
+}
+
+func TestRatesSocketClosed(t *testing.T) {
+	t.Parallel()
+
+	feed := cases.NewFeed()
+	conn := dialRates(t, feed, time.Minute, "?titles=BTC")
+
+	readMessage(t, conn)
+	feed.Close()
+
+	_, _, err := conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error %v", err)
+}
@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+// WebhooksService is the part of cases.Webhooks the API exposes.
+type WebhooksService interface {
+	CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]*entities.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error)
+	Replay(ctx context.Context, id int64) error
+}
+
+var _ WebhooksService = (*cases.Webhooks)(nil)
+
+type webhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+// webhookResponse never includes the secret.
+type webhookResponse struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type webhooksResponse struct {
+	Webhooks []webhookResponse `json:"webhooks"`
+}
+
+type deadLetterResponse struct {
+	ID        int64           `json:"id"`
+	WebhookID int64           `json:"webhook_id"`
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error"`
+	FailedAt  time.Time       `json:"failed_at"`
+}
+
+type deadLettersResponse struct {
+	DeadLetters []deadLetterResponse `json:"dead_letters"`
+}
+
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhookRequest
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid body: %v", err))
+		return
+	}
+
+	webhook := entities.Webhook{
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: make([]entities.EventType, 0, len(req.Events)),
+	}
+
+	for _, eventType := range req.Events {
+		webhook.Events = append(webhook.Events, entities.EventType(eventType))
+	}
+
+	created, err := s.webhooks.CreateWebhook(r.Context(), webhook)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newWebhookResponse(created))
+}
+
+func (s *Server) handleWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := s.webhooks.GetWebhooks(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := webhooksResponse{
+		Webhooks: make([]webhookResponse, 0, len(webhooks)),
+	}
+
+	for _, webhook := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, newWebhookResponse(webhook))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid webhook id %q", r.PathValue("id")))
+		return
+	}
+
+	if err = s.webhooks.DeleteWebhook(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeadLetters(w http.ResponseWriter, r *http.Request) {
+	var (
+		limit int
+		err   error
+	)
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid limit %q", value))
+			return
+		}
+	}
+
+	letters, err := s.webhooks.GetDeadLetters(r.Context(), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := deadLettersResponse{
+		DeadLetters: make([]deadLetterResponse, 0, len(letters)),
+	}
+
+	for _, letter := range letters {
+		resp.DeadLetters = append(resp.DeadLetters, deadLetterResponse{
+			ID:        letter.ID,
+			WebhookID: letter.WebhookID,
+			EventID:   letter.Delivery.EventID,
+			EventType: string(letter.Delivery.EventType),
+			Payload:   letter.Delivery.Payload,
+			Attempts:  letter.Attempts,
+			LastError: letter.LastError,
+			FailedAt:  letter.FailedAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleReplayDeadLetter answers 204 once the letter is delivered and the
+// delivery error otherwise.
+func (s *Server) handleReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, errors.Wrapf(entities.ErrInvalidParam, "invalid dead letter id %q", r.PathValue("id")))
+		return
+	}
+
+	if err = s.webhooks.Replay(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newWebhookResponse(webhook *entities.Webhook) webhookResponse {
+	resp := webhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    make([]string, 0, len(webhook.Events)),
+		CreatedAt: webhook.CreatedAt,
+	}
+
+	for _, eventType := range webhook.Events {
+		resp.Events = append(resp.Events, string(eventType))
+	}
+
+	return resp
+}
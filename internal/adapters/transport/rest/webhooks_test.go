@@ -0,0 +1,150 @@
+package rest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/transport/rest"
+	"crypto-project/internal/adapters/webhook"
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestWebhooks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		status   atomic.Int32
+		received atomic.Pointer[http.Request]
+		body     atomic.Value
+	)
+
+	status.Store(http.StatusGone)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		body.Store(payload)
+		received.Store(r)
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(receiver.Close)
+
+	storage := memory.NewStorage()
+
+	webhooks, err := cases.NewWebhooks(storage, webhook.NewSender(webhook.Config{}), cases.WebhooksConfig{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go webhooks.Run(ctx)
+
+	service, err := cases.NewService(mocks.NewMockCryptoProvider(gomock.NewController(t)), storage)
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(service, rest.Config{Webhooks: webhooks})
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	type hook struct {
+		ID     int64    `json:"id"`
+		URL    string   `json:"url"`
+		Secret string   `json:"secret"`
+		Events []string `json:"events"`
+	}
+
+	var created hook
+
+	payload := `{"url": "` + receiver.URL + `", "secret": "s3cret", "events": ["rates.actualized"]}`
+	require.Equal(t, http.StatusCreated, post(t, httpServer.URL+"/v1/webhooks", payload, &created))
+	require.NotZero(t, created.ID)
+	require.Empty(t, created.Secret)
+	require.Equal(t, []string{"rates.actualized"}, created.Events)
+
+	var errResp errorResponse
+
+	payload = `{"url": "` + receiver.URL + `", "secret": "s3cret", "events": ["rates.deleted"]}`
+	require.Equal(t, http.StatusBadRequest, post(t, httpServer.URL+"/v1/webhooks", payload, &errResp))
+	require.Equal(t, string(entities.CodeInvalidParam), errResp.Error.Code)
+
+	var hooks struct {
+		Webhooks []hook `json:"webhooks"`
+	}
+
+	require.Equal(t, http.StatusOK, get(t, httpServer.URL+"/v1/webhooks", &hooks))
+	require.Len(t, hooks.Webhooks, 1)
+	require.Equal(t, receiver.URL, hooks.Webhooks[0].URL)
+	require.Empty(t, hooks.Webhooks[0].Secret)
+
+	event := entities.NewRatesEvent([]*entities.Coin{
+		{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(100), ActualAt: time.Now()},
+	}, time.Now())
+	webhooks.Publish(context.Background(), event)
+
+	type letter struct {
+		ID        int64  `json:"id"`
+		WebhookID int64  `json:"webhook_id"`
+		EventID   string `json:"event_id"`
+		EventType string `json:"event_type"`
+		Attempts  int    `json:"attempts"`
+		LastError string `json:"last_error"`
+	}
+
+	var letters struct {
+		DeadLetters []letter `json:"dead_letters"`
+	}
+
+	require.Eventually(t, func() bool {
+		return get(t, httpServer.URL+"/v1/webhooks/dead-letters", &letters) == http.StatusOK && len(letters.DeadLetters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deadLetter := letters.DeadLetters[0]
+	require.Equal(t, created.ID, deadLetter.WebhookID)
+	require.Equal(t, event.ID, deadLetter.EventID)
+	require.Equal(t, "rates.actualized", deadLetter.EventType)
+	require.Equal(t, 1, deadLetter.Attempts)
+	require.Contains(t, deadLetter.LastError, "410")
+
+	replayURL := httpServer.URL + "/v1/webhooks/dead-letters/" + strconv.FormatInt(deadLetter.ID, 10) + "/replay"
+
+	require.Equal(t, http.StatusBadGateway, post(t, replayURL, "", &errResp))
+	require.Equal(t, string(entities.CodeDelivery), errResp.Error.Code)
+
+	status.Store(http.StatusNoContent)
+
+	resp, err := http.Post(replayURL, "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	r := received.Load()
+	timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.True(t, webhook.Verify("s3cret", timestamp, body.Load().([]byte), r.Header.Get(webhook.HeaderSignature)))
+
+	require.Equal(t, http.StatusOK, get(t, httpServer.URL+"/v1/webhooks/dead-letters", &letters))
+	require.Empty(t, letters.DeadLetters)
+	require.Equal(t, http.StatusNotFound, post(t, replayURL, "", &errResp))
+
+	req, err := http.NewRequest(http.MethodDelete, httpServer.URL+"/v1/webhooks/"+strconv.FormatInt(created.ID, 10), nil)
+	require.NoError(t, err)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultTimeout = 10 * time.Second
+
+	// HeaderEventID and HeaderEventType describe the delivered event.
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderEventType = "X-Webhook-Event"
+	// HeaderTimestamp is the Unix time the delivery was signed at and
+	// HeaderSignature is "sha256=" followed by the hex HMAC-SHA256, keyed with
+	// the webhook secret, of the timestamp, a dot and the body. Receivers should
+	// reject stale timestamps to prevent replays by third parties.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Config struct {
+	// Timeout bounds every delivery attempt.
+	Timeout    time.Duration
+	HTTPClient *http.Client
+}
+
+// Sender POSTs events as JSON to webhooks.
+type Sender struct {
+	timeout time.Duration
+	client  *http.Client
+}
+
+var _ cases.WebhookSender = (*Sender)(nil)
+
+func NewSender(cfg Config) *Sender {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{}
+	}
+
+	return &Sender{
+		timeout: cfg.Timeout,
+		client:  cfg.HTTPClient,
+	}
+}
+
+type eventPayload struct {
+	ID    string        `json:"id"`
+	Type  string        `json:"type"`
+	At    time.Time     `json:"at"`
+	Rates []ratePayload `json:"rates,omitempty"`
+	Alert *alertPayload `json:"alert,omitempty"`
+}
+
+type ratePayload struct {
+	Title    string          `json:"title"`
+	Quote    string          `json:"quote"`
+	Cost     decimal.Decimal `json:"cost"`
+	ActualAt time.Time       `json:"actual_at"`
+}
+
+type alertPayload struct {
+	RuleID int64           `json:"rule_id"`
+	Title  string          `json:"title"`
+	Quote  string          `json:"quote"`
+	State  string          `json:"state"`
+	Cost   decimal.Decimal `json:"cost"`
+	Value  decimal.Decimal `json:"value"`
+	At     time.Time       `json:"at"`
+}
+
+func (s *Sender) Encode(event *entities.Event) (*entities.Delivery, error) {
+	payload := eventPayload{
+		ID:   event.ID,
+		Type: string(event.Type),
+		At:   event.At,
+	}
+
+	for _, coin := range event.Rates {
+		payload.Rates = append(payload.Rates, ratePayload{
+			Title:    coin.Title,
+			Quote:    coin.Quote,
+			Cost:     coin.Cost,
+			ActualAt: coin.ActualAt,
+		})
+	}
+
+	if alert := event.Alert; alert != nil {
+		payload.Alert = &alertPayload{
+			RuleID: alert.RuleID,
+			Title:  alert.Title,
+			Quote:  alert.Quote,
+			State:  string(alert.State),
+			Cost:   alert.Cost,
+			Value:  alert.Value,
+			At:     alert.At,
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal event")
+	}
+
+	return &entities.Delivery{EventID: event.ID, EventType: event.Type, Payload: body}, nil
+}
+
+// Send POSTs the delivery once. Network errors, timeouts, 408, 429 and 5xx
+// answers are retryable, other non-2xx answers are not.
+func (s *Sender) Send(ctx context.Context, webhook *entities.Webhook, delivery *entities.Delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return entities.NewDeliveryError(false, errors.Wrap(err, "failed to build request"))
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, string(delivery.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return entities.NewDeliveryError(true, errors.Wrap(err, "request failed"))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		retryable := resp.StatusCode == http.StatusRequestTimeout ||
+			resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= http.StatusInternalServerError
+
+		return entities.NewDeliveryError(retryable,
+			errors.Errorf("responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(body))))
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	return nil
+}
+
+// Sign returns the HeaderSignature value of body signed at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the HeaderSignature of body signed at
+// timestamp, in constant time.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,156 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/adapters/webhook"
+	"crypto-project/internal/entities"
+)
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	var (
+		header http.Header
+		body   []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	sender := webhook.NewSender(webhook.Config{})
+
+	at := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	event := &entities.Event{
+		ID:    "0123456789abcdef",
+		Type:  entities.EventRatesActualized,
+		At:    at,
+		Rates: []*entities.Coin{{Title: "BTC", Quote: "USD", Cost: decimal.RequireFromString("42000.5"), ActualAt: at}},
+	}
+
+	delivery, err := sender.Encode(event)
+	require.NoError(t, err)
+	require.Equal(t, event.ID, delivery.EventID)
+	require.Equal(t, event.Type, delivery.EventType)
+	require.JSONEq(t, `{
+		"id": "0123456789abcdef",
+		"type": "rates.actualized",
+		"at": "2024-01-01T12:00:00Z",
+		"rates": [{"title": "BTC", "quote": "USD", "cost": "42000.5", "actual_at": "2024-01-01T12:00:00Z"}]
+	}`, string(delivery.Payload))
+
+	hook := &entities.Webhook{URL: server.URL, Secret: "s3cret"}
+	require.NoError(t, sender.Send(context.Background(), hook, delivery))
+
+	require.Equal(t, delivery.Payload, body)
+	require.Equal(t, "application/json", header.Get("Content-Type"))
+	require.Equal(t, event.ID, header.Get(webhook.HeaderEventID))
+	require.Equal(t, "rates.actualized", header.Get(webhook.HeaderEventType))
+
+	timestamp, err := strconv.ParseInt(header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+	require.True(t, webhook.Verify("s3cret", timestamp, body, header.Get(webhook.HeaderSignature)))
+	require.False(t, webhook.Verify("other", timestamp, body, header.Get(webhook.HeaderSignature)))
+	require.False(t, webhook.Verify("s3cret", timestamp+1, body, header.Get(webhook.HeaderSignature)))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac s3cret
+	require.Equal(t,
+		"sha256=97926816e98fbb41ccb1673225ff29a2f35369099990e1b1561651e7bd097ebf",
+		webhook.Sign("s3cret", 1700000000, []byte("{}")),
+	)
+}
+
+func TestEncodeAlert(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	event := entities.NewAlertEvent(&entities.AlertEvent{
+		ID: 3, RuleID: 7, Title: "BTC", Quote: "USD", State: entities.AlertFiring,
+		Cost: decimal.NewFromInt(110), Value: decimal.NewFromInt(10), At: at,
+	})
+
+	delivery, err := webhook.NewSender(webhook.Config{}).Encode(event)
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(delivery.Payload, &payload))
+	require.Equal(t, "alert.fired", payload["type"])
+	require.Equal(t, map[string]any{
+		"rule_id": float64(7), "title": "BTC", "quote": "USD", "state": "firing", "cost": "110", "value": "10", "at": "2024-01-01T12:00:00Z",
+	}, payload["alert"])
+	require.NotContains(t, payload, "rates")
+}
+
+func TestSendErrors(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name          string
+		status        int
+		wantRetryable bool
+	}{
+		{name: "server error", status: http.StatusInternalServerError, wantRetryable: true},
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantRetryable: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, wantRetryable: true},
+		{name: "request timeout", status: http.StatusRequestTimeout, wantRetryable: true},
+		{name: "gone", status: http.StatusGone},
+		{name: "unauthorized", status: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			t.Cleanup(server.Close)
+
+			err := webhook.NewSender(webhook.Config{}).Send(
+				context.Background(),
+				&entities.Webhook{URL: server.URL, Secret: "s3cret"},
+				&entities.Delivery{EventID: "1", EventType: entities.EventRatesActualized, Payload: []byte("{}")},
+			)
+			require.ErrorIs(t, err, entities.ErrDelivery)
+			require.Equal(t, tc.wantRetryable, entities.IsRetryable(err))
+		})
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(release) })
+
+		err := webhook.NewSender(webhook.Config{Timeout: 50 * time.Millisecond}).Send(
+			context.Background(),
+			&entities.Webhook{URL: server.URL, Secret: "s3cret"},
+			&entities.Delivery{EventID: "1", EventType: entities.EventRatesActualized, Payload: []byte("{}")},
+		)
+		require.ErrorIs(t, err, entities.ErrDelivery)
+		require.True(t, entities.IsRetryable(err))
+	})
+}
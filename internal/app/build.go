@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/adapters/metrics"
+	"crypto-project/internal/adapters/provider/binance"
+	"crypto-project/internal/adapters/provider/coingecko"
+	"crypto-project/internal/adapters/provider/consensus"
+	"crypto-project/internal/adapters/provider/fallback"
+	"crypto-project/internal/adapters/provider/named"
+	"crypto-project/internal/adapters/storage/memory"
+	"crypto-project/internal/adapters/storage/postgres"
+	"crypto-project/internal/cases"
+)
+
+// Storage keeps the rates, the alert rules and the webhooks.
+type Storage interface {
+	cases.Storage
+	cases.AlertStorage
+	cases.WebhookStorage
+}
+
+// BuildStorage returns the configured storage and a function releasing it.
+// The storage is instrumented with m, if not nil.
+func BuildStorage(ctx context.Context, cfg Config, m *metrics.Metrics) (Storage, func(), error) {
+	storage, closeStorage, err := buildStorage(ctx, cfg)
+	if err != nil || m == nil {
+		return storage, closeStorage, err
+	}
+
+	return metrics.NewStorage(m, storage), closeStorage, nil
+}
+
+func buildStorage(ctx context.Context, cfg Config) (Storage, func(), error) {
+	switch cfg.Storage {
+	case StoragePostgres:
+		storage, err := postgres.NewStorage(ctx, postgres.Config{
+			DSN:              cfg.PostgresDSN,
+			MaxConns:         cfg.PostgresMaxConns,
+			MigrateOnStartup: cfg.PostgresAutoMigrate,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to create postgres storage")
+		}
+
+		return storage, storage.Close, nil
+	default:
+		return memory.NewStorage(), func() {}, nil
+	}
+}
+
+// BuildProvider returns the configured provider, combining several of them
+// according to cfg.ProviderMode. Every provider is instrumented with m, if not
+// nil.
+func BuildProvider(cfg Config, m *metrics.Metrics) (cases.CryptoProvider, error) {
+	providers := make([]named.Provider, 0, len(cfg.Providers))
+
+	for _, name := range cfg.Providers {
+		var (
+			provider cases.CryptoProvider
+			err      error
+		)
+
+		switch name {
+		case ProviderCoinGecko:
+			provider, err = coingecko.NewProvider(coingecko.Config{
+				BaseURL:      cfg.CoinGeckoBaseURL,
+				APIKey:       cfg.CoinGeckoAPIKey,
+				APIKeyHeader: cfg.CoinGeckoAPIKeyHeader,
+				Timeout:      cfg.ProviderRequestTimeout,
+			})
+		case ProviderBinance:
+			provider, err = binance.NewProvider(binance.Config{
+				BaseURL:        cfg.BinanceBaseURL,
+				QuoteUSDSymbol: cfg.BinanceQuoteUSDSymbol,
+				Timeout:        cfg.ProviderRequestTimeout,
+			})
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create %s provider", name)
+		}
+
+		if m != nil {
+			provider = metrics.NewProvider(m, name, provider)
+		}
+
+		providers = append(providers, named.Provider{Name: name, Provider: provider})
+	}
+
+	if len(providers) == 1 {
+		return providers[0].Provider, nil
+	}
+
+	if cfg.ProviderMode == ProviderModeConsensus {
+		return consensus.NewProvider(consensus.Config{
+			Providers:    providers,
+			MaxDeviation: cfg.ConsensusMaxDeviation,
+			Quorum:       cfg.ConsensusQuorum,
+		})
+	}
+
+	provider, err := fallback.NewProvider(providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	if m != nil {
+		provider.OnReport = func(report fallback.Report) {
+			m.ObserveServed(report.Served, report.Missing)
+		}
+	}
+
+	return provider, nil
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+const (
+	StorageMemory   = "memory"
+	StoragePostgres = "postgres"
+
+	ProviderCoinGecko = "coingecko"
+	ProviderBinance   = "binance"
+
+	ProviderModeFallback  = "fallback"
+	ProviderModeConsensus = "consensus"
+)
+
+// Config is read from the environment, see LoadConfig for the variable names.
+type Config struct {
+	HTTPAddr        string
+	GRPCAddr        string
+	RequestTimeout  time.Duration
+	ShutdownTimeout time.Duration
+	StreamHeartbeat time.Duration
+
+	Storage             string
+	PostgresDSN         string
+	PostgresMaxConns    int32
+	PostgresAutoMigrate bool
+
+	// Providers are combined according to ProviderMode when there are several.
+	Providers              []string
+	ProviderMode           string
+	ConsensusMaxDeviation  float64
+	ConsensusQuorum        int
+	CoinGeckoBaseURL       string
+	CoinGeckoAPIKey        string
+	CoinGeckoAPIKeyHeader  string
+	BinanceBaseURL         string
+	BinanceQuoteUSDSymbol  string
+	ProviderRequestTimeout time.Duration
+
+	ActualizeInterval time.Duration
+	ActualizeJitter   time.Duration
+	ActualizeTimeout  time.Duration
+
+	// Zero webhook settings fall back to the cases.DefaultWebhook* values.
+	WebhookMaxAttempts    int
+	WebhookInitialBackoff time.Duration
+	WebhookMaxBackoff     time.Duration
+	WebhookTimeout        time.Duration
+	WebhookWorkers        int
+}
+
+// LoadConfig reads the configuration through getenv, normally os.Getenv.
+func LoadConfig(getenv func(string) string) (Config, error) {
+	l := loader{getenv: getenv}
+
+	cfg := Config{
+		HTTPAddr:        l.string("HTTP_ADDR", ":8080"),
+		GRPCAddr:        l.string("GRPC_ADDR", ":9090"),
+		RequestTimeout:  l.duration("REQUEST_TIMEOUT", 15*time.Second),
+		ShutdownTimeout: l.duration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		StreamHeartbeat: l.duration("STREAM_HEARTBEAT", 0),
+
+		Storage:             l.string("STORAGE", StorageMemory),
+		PostgresDSN:         l.string("POSTGRES_DSN", ""),
+		PostgresMaxConns:    int32(l.int("POSTGRES_MAX_CONNS", 0)),
+		PostgresAutoMigrate: l.bool("POSTGRES_AUTO_MIGRATE", false),
+
+		Providers:              l.list("PROVIDERS", []string{ProviderCoinGecko}),
+		ProviderMode:           l.string("PROVIDER_MODE", ProviderModeFallback),
+		ConsensusMaxDeviation:  l.float("CONSENSUS_MAX_DEVIATION", 0),
+		ConsensusQuorum:        l.int("CONSENSUS_QUORUM", 0),
+		CoinGeckoBaseURL:       l.string("COINGECKO_BASE_URL", ""),
+		CoinGeckoAPIKey:        l.string("COINGECKO_API_KEY", ""),
+		CoinGeckoAPIKeyHeader:  l.string("COINGECKO_API_KEY_HEADER", ""),
+		BinanceBaseURL:         l.string("BINANCE_BASE_URL", ""),
+		BinanceQuoteUSDSymbol:  l.string("BINANCE_QUOTE_USD_SYMBOL", ""),
+		ProviderRequestTimeout: l.duration("PROVIDER_REQUEST_TIMEOUT", 0),
+
+		ActualizeInterval: l.duration("ACTUALIZE_INTERVAL", time.Minute),
+		ActualizeJitter:   l.duration("ACTUALIZE_JITTER", 0),
+		ActualizeTimeout:  l.duration("ACTUALIZE_TIMEOUT", 30*time.Second),
+
+		WebhookMaxAttempts:    l.int("WEBHOOK_MAX_ATTEMPTS", 0),
+		WebhookInitialBackoff: l.duration("WEBHOOK_INITIAL_BACKOFF", 0),
+		WebhookMaxBackoff:     l.duration("WEBHOOK_MAX_BACKOFF", 0),
+		WebhookTimeout:        l.duration("WEBHOOK_TIMEOUT", 0),
+		WebhookWorkers:        l.int("WEBHOOK_WORKERS", 0),
+	}
+
+	if l.err != nil {
+		return Config{}, l.err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	switch c.Storage {
+	case StorageMemory:
+	case StoragePostgres:
+		if c.PostgresDSN == "" {
+			return errors.Wrap(entities.ErrInvalidParam, "POSTGRES_DSN is required for postgres storage")
+		}
+	default:
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown STORAGE %q", c.Storage)
+	}
+
+	if len(c.Providers) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "PROVIDERS cannot be empty")
+	}
+
+	for _, provider := range c.Providers {
+		if provider != ProviderCoinGecko && provider != ProviderBinance {
+			return errors.Wrapf(entities.ErrInvalidParam, "unknown provider %q", provider)
+		}
+	}
+
+	if c.ProviderMode != ProviderModeFallback && c.ProviderMode != ProviderModeConsensus {
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown PROVIDER_MODE %q", c.ProviderMode)
+	}
+
+	if c.ActualizeInterval <= 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "ACTUALIZE_INTERVAL must be positive")
+	}
+
+	return nil
+}
+
+// loader keeps the first parse error so that LoadConfig reads like a table.
+type loader struct {
+	getenv func(string) string
+	err    error
+}
+
+func (l *loader) string(key, def string) string {
+	if value := strings.TrimSpace(l.getenv(key)); value != "" {
+		return value
+	}
+
+	return def
+}
+
+func (l *loader) list(key string, def []string) []string {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+
+	items := make([]string, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	l.fail(key, err)
+
+	return d
+}
+
+func (l *loader) int(key string, def int) int {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(value)
+	l.fail(key, err)
+
+	return n
+}
+
+func (l *loader) float(key string, def float64) float64 {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	l.fail(key, err)
+
+	return f
+}
+
+func (l *loader) bool(key string, def bool) bool {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+
+	b, err := strconv.ParseBool(value)
+	l.fail(key, err)
+
+	return b
+}
+
+func (l *loader) fail(key string, err error) {
+	if err != nil && l.err == nil {
+		l.err = errors.Wrapf(entities.ErrInvalidParam, "invalid %s: %v", key, err)
+	}
+}
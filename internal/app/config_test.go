@@ -0,0 +1,96 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/adapters/metrics"
+	"crypto-project/internal/adapters/provider/fallback"
+	"crypto-project/internal/app"
+	"crypto-project/internal/entities"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := app.LoadConfig(env(nil))
+	require.NoError(t, err)
+	require.Equal(t, ":8080", cfg.HTTPAddr)
+	require.Equal(t, ":9090", cfg.GRPCAddr)
+	require.Equal(t, app.StorageMemory, cfg.Storage)
+	require.Equal(t, []string{app.ProviderCoinGecko}, cfg.Providers)
+	require.Equal(t, time.Minute, cfg.ActualizeInterval)
+
+	cfg, err = app.LoadConfig(env(map[string]string{
+		"STORAGE":               "postgres",
+		"POSTGRES_DSN":          "postgres://localhost/rates",
+		"POSTGRES_AUTO_MIGRATE": "true",
+		"PROVIDERS":             " coingecko, binance ,",
+		"PROVIDER_MODE":         "consensus",
+		"CONSENSUS_QUORUM":      "2",
+		"ACTUALIZE_INTERVAL":    "30s",
+		"WEBHOOK_MAX_BACKOFF":   "5m",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, app.StoragePostgres, cfg.Storage)
+	require.True(t, cfg.PostgresAutoMigrate)
+	require.Equal(t, []string{app.ProviderCoinGecko, app.ProviderBinance}, cfg.Providers)
+	require.Equal(t, app.ProviderModeConsensus, cfg.ProviderMode)
+	require.Equal(t, 2, cfg.ConsensusQuorum)
+	require.Equal(t, 30*time.Second, cfg.ActualizeInterval)
+	require.Equal(t, 5*time.Minute, cfg.WebhookMaxBackoff)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name string
+		vars map[string]string
+	}{
+		{name: "malformed duration", vars: map[string]string{"ACTUALIZE_INTERVAL": "often"}},
+		{name: "non-positive interval", vars: map[string]string{"ACTUALIZE_INTERVAL": "0s"}},
+		{name: "malformed bool", vars: map[string]string{"POSTGRES_AUTO_MIGRATE": "sure"}},
+		{name: "unknown storage", vars: map[string]string{"STORAGE": "redis"}},
+		{name: "postgres without dsn", vars: map[string]string{"STORAGE": "postgres"}},
+		{name: "unknown provider", vars: map[string]string{"PROVIDERS": "kraken"}},
+		{name: "unknown provider mode", vars: map[string]string{"PROVIDER_MODE": "random"}},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := app.LoadConfig(env(tc.vars))
+			require.ErrorIs(t, err, entities.ErrInvalidParam)
+		})
+	}
+}
+
+func TestBuildProvider(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []string{app.ProviderModeFallback, app.ProviderModeConsensus} {
+		cfg, err := app.LoadConfig(env(map[string]string{"PROVIDERS": "coingecko,binance", "PROVIDER_MODE": mode}))
+		require.NoError(t, err)
+
+		provider, err := app.BuildProvider(cfg, nil)
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+	}
+
+	cfg, err := app.LoadConfig(env(map[string]string{"PROVIDERS": "coingecko,binance"}))
+	require.NoError(t, err)
+
+	// The metrics learn which provider of the chain served every title.
+	provider, err := app.BuildProvider(cfg, metrics.New())
+	require.NoError(t, err)
+	require.IsType(t, &fallback.Provider{}, provider)
+	require.NotNil(t, provider.(*fallback.Provider).OnReport)
+}
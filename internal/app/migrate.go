@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/adapters/storage/postgres"
+	"crypto-project/internal/entities"
+)
+
+// Migrate runs a migration command on the Postgres database at dsn: up applies
+// every pending migration, down rolls back the latest steps ones and version
+// changes nothing. The resulting schema version is written to w.
+func Migrate(ctx context.Context, w io.Writer, dsn, command string, steps int) error {
+	switch command {
+	case "up", "down", "version":
+	default:
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown migrate command %q", command)
+	}
+
+	storage, err := postgres.NewStorage(ctx, postgres.Config{DSN: dsn, MaxConns: 1})
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	switch command {
+	case "up":
+		err = storage.MigrateUp(ctx)
+	case "down":
+		err = storage.MigrateDown(ctx, steps)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	version, err := storage.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "schema version: %d\n", version)
+
+	return err
+}
@@ -0,0 +1,20 @@
+package app_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/app"
+	"crypto-project/internal/entities"
+)
+
+func TestMigrateUnknownCommand(t *testing.T) {
+	t.Parallel()
+
+	// The command is checked before connecting.
+	err := app.Migrate(context.Background(), io.Discard, "postgres://localhost/rates", "sideways", 1)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
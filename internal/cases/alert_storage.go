@@ -0,0 +1,28 @@
+package cases
+
+import (
+	"context"
+
+	"crypto-project/internal/entities"
+)
+
+// AlertStorage keeps alert rules, their evaluation state and the events they
+// produced.
+//
+//go:generate mockgen -source=alert_storage.go -destination=mocks/alert_storage_mock.go -package=mocks
+type AlertStorage interface {
+	// CreateAlertRule stores rule and returns it with its ID assigned.
+	CreateAlertRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error)
+	// GetAlertRules returns every rule ordered by ID.
+	GetAlertRules(ctx context.Context) ([]*entities.AlertRule, error)
+	// DeleteAlertRule deletes a rule and its events. It returns
+	// entities.ErrNotFound for an unknown id.
+	DeleteAlertRule(ctx context.Context, id int64) error
+	// SaveAlertEvaluation atomically updates the Firing and FiredAt state of
+	// rules and appends events, assigning their IDs. Rules deleted in the
+	// meantime are skipped along with their events.
+	SaveAlertEvaluation(ctx context.Context, rules []*entities.AlertRule, events []*entities.AlertEvent) error
+	// GetAlertEvents returns up to limit of the latest events, newest first,
+	// of the rule with ruleID or of every rule when ruleID is 0.
+	GetAlertEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error)
+}
@@ -0,0 +1,244 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultAlertEventsLimit = 100
+	MaxAlertEventsLimit     = 1000
+)
+
+// Alerts manages alert rules and evaluates them against the stored rates.
+type Alerts struct {
+	Storage      Storage
+	AlertStorage AlertStorage
+}
+
+func NewAlerts(storage Storage, alertStorage AlertStorage) (*Alerts, error) {
+	if storage == nil || storage == Storage(nil) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "storage not set")
+	}
+
+	if alertStorage == nil || alertStorage == AlertStorage(nil) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "alert storage not set")
+	}
+
+	return &Alerts{
+		Storage:      storage,
+		AlertStorage: alertStorage,
+	}, nil
+}
+
+// CreateRule validates and stores a new rule. Its ID and evaluation state are
+// assigned by the storage, an empty quote means entities.DefaultQuote.
+func (a *Alerts) CreateRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error) {
+	quote, err := entities.NormalizeQuote(rule.Quote)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.ID, rule.Quote, rule.Firing, rule.FiredAt = 0, quote, false, time.Time{}
+
+	if err = rule.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid rule")
+	}
+
+	created, err := a.AlertStorage.CreateAlertRule(ctx, rule)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create alert rule")
+	}
+
+	return created, nil
+}
+
+func (a *Alerts) GetRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	rules, err := a.AlertStorage.GetAlertRules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get alert rules")
+	}
+
+	return rules, nil
+}
+
+func (a *Alerts) DeleteRule(ctx context.Context, id int64) error {
+	if err := a.AlertStorage.DeleteAlertRule(ctx, id); err != nil {
+		return errors.Wrapf(err, "failed to delete alert rule %d", id)
+	}
+
+	return nil
+}
+
+// GetEvents returns the latest events, newest first, of the rule with ruleID
+// or of every rule when ruleID is 0. A zero limit means
+// DefaultAlertEventsLimit.
+func (a *Alerts) GetEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error) {
+	if limit == 0 {
+		limit = DefaultAlertEventsLimit
+	}
+
+	if limit < 0 || limit > MaxAlertEventsLimit {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", MaxAlertEventsLimit)
+	}
+
+	events, err := a.AlertStorage.GetAlertEvents(ctx, ruleID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get alert events")
+	}
+
+	return events, nil
+}
+
+// Evaluate checks every rule against the latest stored rates and returns the
+// events of the rules that started firing or resolved. Rules whose rates are
+// not stored, or not stored far enough back for AlertChange, keep their state.
+func (a *Alerts) Evaluate(ctx context.Context) ([]*entities.AlertEvent, error) {
+	rules, err := a.AlertStorage.GetAlertRules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get alert rules")
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	latest, err := a.latestRates(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	changed := make([]*entities.AlertRule, 0)
+	events := make([]*entities.AlertEvent, 0)
+
+	for _, rule := range rules {
+		coin, ok := latest[pair{title: rule.Title, quote: rule.Quote}]
+		if !ok {
+			continue
+		}
+
+		value, ok, err := a.observe(ctx, rule, coin)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate alert rule %d", rule.ID)
+		}
+
+		if !ok {
+			continue
+		}
+
+		var state entities.AlertState
+
+		switch matched := matches(rule, value); {
+		case matched && !rule.Firing:
+			if !rule.FiredAt.IsZero() && now.Sub(rule.FiredAt) < rule.Cooldown {
+				continue
+			}
+
+			state = entities.AlertFiring
+			rule.Firing, rule.FiredAt = true, now
+		case !matched && rule.Firing:
+			state = entities.AlertResolved
+			rule.Firing = false
+		default:
+			continue
+		}
+
+		changed = append(changed, rule)
+		events = append(events, &entities.AlertEvent{
+			RuleID: rule.ID,
+			Title:  rule.Title,
+			Quote:  rule.Quote,
+			State:  state,
+			Cost:   coin.Cost,
+			Value:  value,
+			At:     now,
+		})
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if err = a.AlertStorage.SaveAlertEvaluation(ctx, changed, events); err != nil {
+		return nil, errors.Wrap(err, "failed to save alert evaluation")
+	}
+
+	return events, nil
+}
+
+type pair struct {
+	title string
+	quote string
+}
+
+// latestRates returns the latest rates of the titles rules watch.
+func (a *Alerts) latestRates(ctx context.Context, rules []*entities.AlertRule) (map[pair]*entities.Coin, error) {
+	titles := make(map[string][]string)
+	seen := make(map[pair]struct{}, len(rules))
+
+	for _, rule := range rules {
+		key := pair{title: rule.Title, quote: rule.Quote}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			titles[rule.Quote] = append(titles[rule.Quote], rule.Title)
+		}
+	}
+
+	latest := make(map[pair]*entities.Coin, len(seen))
+
+	for quote, quoteTitles := range titles {
+		coins, err := a.Storage.GetActualCoin(ctx, quoteTitles, quote)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get actual %s coins", quote)
+		}
+
+		for _, coin := range coins {
+			latest[pair{title: coin.Title, quote: coin.Quote}] = coin
+		}
+	}
+
+	return latest, nil
+}
+
+// observe returns the value rule compares to its threshold, and false if there
+// is not enough history to compute it: no rate within ChangeTolerance of the
+// start of the window.
+func (a *Alerts) observe(ctx context.Context, rule *entities.AlertRule, coin *entities.Coin) (decimal.Decimal, bool, error) {
+	if rule.Condition != entities.AlertChange {
+		return coin.Cost, true, nil
+	}
+
+	pastAt := coin.ActualAt.Add(-rule.Window)
+
+	past, err := a.Storage.GetHistoricalCoin(ctx, []string{rule.Title}, rule.Quote, pastAt)
+	if err != nil {
+		return decimal.Decimal{}, false, errors.Wrap(err, "failed to get historical coin")
+	}
+
+	if len(past) == 0 || past[0].ActualAt.Before(pastAt.Add(-ChangeTolerance)) {
+		return decimal.Decimal{}, false, nil
+	}
+
+	percent := coin.Cost.Sub(past[0].Cost).Mul(decimal.NewFromInt(100)).DivRound(past[0].Cost, entities.PriceScale)
+
+	return percent, true, nil
+}
+
+func matches(rule *entities.AlertRule, value decimal.Decimal) bool {
+	switch rule.Condition {
+	case entities.AlertAbove:
+		return value.GreaterThanOrEqual(rule.Threshold)
+	case entities.AlertBelow:
+		return value.LessThanOrEqual(rule.Threshold)
+	case entities.AlertChange:
+		return value.Abs().GreaterThanOrEqual(rule.Threshold)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,253 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestAlertsEvaluate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	usd := func(title, cost string, actualAt time.Time) *entities.Coin {
+		return &entities.Coin{Title: title, Quote: "USD", Cost: decimal.RequireFromString(cost), ActualAt: actualAt}
+	}
+
+	above := func(threshold string) *entities.AlertRule {
+		return &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.RequireFromString(threshold)}
+	}
+
+	testTable := []struct {
+		name           string
+		rule           *entities.AlertRule
+		latest         []*entities.Coin
+		history        []*entities.Coin
+		expectedState  entities.AlertState
+		expectedValue  string
+		expectedFiring bool
+	}{
+		{
+			name:           "starts firing",
+			rule:           above("100"),
+			latest:         []*entities.Coin{usd("BTC", "150", now)},
+			expectedState:  entities.AlertFiring,
+			expectedValue:  "150",
+			expectedFiring: true,
+		},
+		{
+			name:   "keeps firing",
+			rule:   &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100), Firing: true, FiredAt: now.Add(-time.Hour)},
+			latest: []*entities.Coin{usd("BTC", "150", now)},
+		},
+		{
+			name:          "resolves",
+			rule:          &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100), Firing: true, FiredAt: now.Add(-time.Hour)},
+			latest:        []*entities.Coin{usd("BTC", "90", now)},
+			expectedState: entities.AlertResolved,
+			expectedValue: "90",
+		},
+		{
+			name:   "does not match",
+			rule:   above("200"),
+			latest: []*entities.Coin{usd("BTC", "150", now)},
+		},
+		{
+			name:   "cooling down",
+			rule:   &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100), Cooldown: time.Hour, FiredAt: now.Add(-time.Minute)},
+			latest: []*entities.Coin{usd("BTC", "150", now)},
+		},
+		{
+			name:           "cooled down",
+			rule:           &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100), Cooldown: time.Hour, FiredAt: now.Add(-2 * time.Hour)},
+			latest:         []*entities.Coin{usd("BTC", "150", now)},
+			expectedState:  entities.AlertFiring,
+			expectedValue:  "150",
+			expectedFiring: true,
+		},
+		{
+			name:           "below",
+			rule:           &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertBelow, Threshold: decimal.NewFromInt(100)},
+			latest:         []*entities.Coin{usd("BTC", "100", now)},
+			expectedState:  entities.AlertFiring,
+			expectedValue:  "100",
+			expectedFiring: true,
+		},
+		{
+			name:           "percent drop within window",
+			rule:           &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertChange, Threshold: decimal.NewFromInt(5), Window: time.Hour},
+			latest:         []*entities.Coin{usd("BTC", "94", now)},
+			history:        []*entities.Coin{usd("BTC", "100", now.Add(-time.Hour))},
+			expectedState:  entities.AlertFiring,
+			expectedValue:  "-6",
+			expectedFiring: true,
+		},
+		{
+			name:    "percent change below threshold",
+			rule:    &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertChange, Threshold: decimal.NewFromInt(5), Window: time.Hour},
+			latest:  []*entities.Coin{usd("BTC", "104", now)},
+			history: []*entities.Coin{usd("BTC", "100", now.Add(-time.Hour))},
+		},
+		{
+			name:    "outdated history",
+			rule:    &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertChange, Threshold: decimal.NewFromInt(5), Window: time.Hour},
+			latest:  []*entities.Coin{usd("BTC", "94", now)},
+			history: []*entities.Coin{usd("BTC", "100", now.Add(-time.Hour-cases.ChangeTolerance-time.Minute))},
+		},
+		{
+			name:   "no history",
+			rule:   &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertChange, Threshold: decimal.NewFromInt(5), Window: time.Hour},
+			latest: []*entities.Coin{usd("BTC", "94", now)},
+		},
+		{
+			name: "no rates",
+			rule: above("100"),
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorage(ctrl)
+			mockAlertStorage := mocks.NewMockAlertStorage(ctrl)
+
+			alerts, err := cases.NewAlerts(mockStorage, mockAlertStorage)
+			require.NoError(t, err)
+
+			mockAlertStorage.EXPECT().
+				GetAlertRules(gomock.Any()).
+				Return([]*entities.AlertRule{tc.rule}, nil)
+			mockStorage.EXPECT().
+				GetActualCoin(gomock.Any(), []string{"BTC"}, "USD").
+				Return(tc.latest, nil)
+
+			if tc.rule.Condition == entities.AlertChange && len(tc.latest) > 0 {
+				mockStorage.EXPECT().
+					GetHistoricalCoin(gomock.Any(), []string{"BTC"}, "USD", tc.latest[0].ActualAt.Add(-tc.rule.Window)).
+					Return(tc.history, nil)
+			}
+
+			var saved []*entities.AlertEvent
+
+			if tc.expectedState != "" {
+				mockAlertStorage.EXPECT().
+					SaveAlertEvaluation(gomock.Any(), []*entities.AlertRule{tc.rule}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []*entities.AlertRule, events []*entities.AlertEvent) error {
+						saved = events
+						return nil
+					})
+			}
+
+			events, err := alerts.Evaluate(context.Background())
+			require.NoError(t, err)
+
+			if tc.expectedState == "" {
+				require.Empty(t, events)
+				return
+			}
+
+			require.Len(t, events, 1)
+			require.Equal(t, saved, events)
+			require.Equal(t, tc.rule.ID, events[0].RuleID)
+			require.Equal(t, tc.expectedState, events[0].State)
+			require.Equal(t, tc.expectedValue, events[0].Value.String())
+			require.Equal(t, tc.expectedFiring, tc.rule.Firing)
+		})
+	}
+}
+
+func TestAlertsEvaluateErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockAlertStorage := mocks.NewMockAlertStorage(ctrl)
+
+	alerts := &cases.Alerts{Storage: mockStorage, AlertStorage: mockAlertStorage}
+
+	mockAlertStorage.EXPECT().
+		GetAlertRules(gomock.Any()).
+		Return(nil, entities.ErrStorage)
+
+	_, err := alerts.Evaluate(context.Background())
+	require.ErrorIs(t, err, entities.ErrStorage)
+
+	mockAlertStorage.EXPECT().
+		GetAlertRules(gomock.Any()).
+		Return([]*entities.AlertRule{{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(1)}}, nil)
+	mockStorage.EXPECT().
+		GetActualCoin(gomock.Any(), []string{"BTC"}, "USD").
+		Return(nil, entities.ErrStorage)
+
+	_, err = alerts.Evaluate(context.Background())
+	require.ErrorIs(t, err, entities.ErrStorage)
+}
+
+func TestAlertsCreateRule(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockAlertStorage := mocks.NewMockAlertStorage(ctrl)
+
+	alerts := &cases.Alerts{Storage: mocks.NewMockStorage(ctrl), AlertStorage: mockAlertStorage}
+
+	rule := entities.AlertRule{ID: 7, Title: "BTC", Quote: "eur", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(1), Firing: true}
+	expected := entities.AlertRule{Title: "BTC", Quote: "EUR", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(1)}
+
+	mockAlertStorage.EXPECT().
+		CreateAlertRule(gomock.Any(), expected).
+		DoAndReturn(func(_ context.Context, rule entities.AlertRule) (*entities.AlertRule, error) {
+			rule.ID = 1
+			return &rule, nil
+		})
+
+	created, err := alerts.CreateRule(context.Background(), rule)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), created.ID)
+	require.Equal(t, "EUR", created.Quote)
+	require.False(t, created.Firing)
+
+	_, err = alerts.CreateRule(context.Background(), entities.AlertRule{Title: "BTC", Condition: entities.AlertChange, Threshold: decimal.NewFromInt(1)})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = alerts.GetEvents(context.Background(), 0, cases.MaxAlertEventsLimit+1)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestActualizeRatesEvaluatesAlerts(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockCryptoProvider := mocks.NewMockCryptoProvider(ctrl)
+	mockAlertStorage := mocks.NewMockAlertStorage(ctrl)
+
+	service := &cases.Service{
+		Storage:  mockStorage,
+		Provider: mockCryptoProvider,
+		Alerts:   &cases.Alerts{Storage: mockStorage, AlertStorage: mockAlertStorage},
+	}
+
+	coins := []*entities.Coin{{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(150), ActualAt: time.Now()}}
+
+	gomock.InOrder(
+		mockStorage.EXPECT().GetQuotesList(gomock.Any()).Return([]string{"USD"}, nil),
+		mockStorage.EXPECT().GetCoinsList(gomock.Any(), "USD").Return([]string{"BTC"}, nil),
+		mockCryptoProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}, "USD").Return(coins, nil),
+		mockStorage.EXPECT().Store(gomock.Any(), coins).Return(nil),
+		mockAlertStorage.EXPECT().GetAlertRules(gomock.Any()).Return(nil, entities.ErrStorage),
+	)
+
+	require.ErrorIs(t, service.ActualizeRates(context.Background()), entities.ErrStorage)
+}
@@ -0,0 +1,244 @@
+package cases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/entities"
+)
+
+// DefaultMaxRateAge is the oldest a rate Convert uses may be when the caller
+// does not bound it.
+const DefaultMaxRateAge = 10 * time.Minute
+
+// ratePath prices one unit of an asset in another as num/den, so that
+// triangulated rates are divided and rounded only once.
+type ratePath struct {
+	num  decimal.Decimal
+	den  decimal.Decimal
+	legs []*entities.Coin
+}
+
+func (p ratePath) staleTitles(cutoff time.Time) []string {
+	var titles []string
+
+	for _, leg := range p.legs {
+		if leg.ActualAt.Before(cutoff) {
+			titles = append(titles, leg.Title)
+		}
+	}
+
+	return titles
+}
+
+// Convert expresses amount of from in to using the latest stored rates. from
+// and to are titles or quotes in any case, e.g. ETH, sol or EUR. A pair is used
+// directly or inverted when it is stored, otherwise the rate is triangulated
+// through a common quote, entities.DefaultQuote first. Rates older than maxAge,
+// DefaultMaxRateAge when zero, are not used: if only stale paths exist Convert
+// fails with entities.ErrStaleRate. Converting an asset to itself needs no
+// rate, only that the asset is stored.
+func (s *Service) Convert(
+	ctx context.Context,
+	from, to string,
+	amount decimal.Decimal,
+	maxAge time.Duration,
+) (*entities.Conversion, error) {
+	from = strings.ToUpper(strings.TrimSpace(from))
+	to = strings.ToUpper(strings.TrimSpace(to))
+
+	if from == "" || to == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from and to cannot be empty")
+	}
+
+	if !amount.IsPositive() {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "amount must be positive")
+	}
+
+	if maxAge < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "max rate age cannot be negative")
+	}
+
+	if maxAge == 0 {
+		maxAge = DefaultMaxRateAge
+	}
+
+	if from == to {
+		if err := s.requireAsset(ctx, from); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s to %s", from, to)
+		}
+
+		return &entities.Conversion{
+			From:     from,
+			To:       to,
+			Amount:   amount,
+			Result:   amount,
+			Rate:     decimal.NewFromInt(1),
+			ActualAt: time.Now(),
+		}, nil
+	}
+
+	path, err := s.findRatePath(ctx, from, to, time.Now().Add(-maxAge))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s to %s", from, to)
+	}
+
+	conversion := &entities.Conversion{
+		From:   from,
+		To:     to,
+		Amount: amount,
+		Result: amount.Mul(path.num).DivRound(path.den, entities.PriceScale),
+		Rate:   path.num.DivRound(path.den, entities.PriceScale),
+		Legs:   path.legs,
+	}
+
+	for _, leg := range path.legs {
+		if conversion.ActualAt.IsZero() || leg.ActualAt.Before(conversion.ActualAt) {
+			conversion.ActualAt = leg.ActualAt
+		}
+	}
+
+	return conversion, nil
+}
+
+// requireAsset fails with entities.ErrNotFound unless asset is a stored quote
+// or has a stored rate in one.
+func (s *Service) requireAsset(ctx context.Context, asset string) error {
+	quotes, err := s.pivotQuotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(quotes, asset) {
+		return nil
+	}
+
+	for _, quote := range quotes {
+		coins, err := s.Storage.GetActualCoin(ctx, []string{asset}, quote)
+		if err != nil {
+			return errors.Wrap(err, "failed to get actual coin")
+		}
+
+		if len(coins) > 0 {
+			return nil
+		}
+	}
+
+	return entities.NewNotFoundError([]string{asset})
+}
+
+// findRatePath returns the first path from from to to whose legs are all
+// actual at cutoff or later, trying the pair itself before the stored quotes.
+func (s *Service) findRatePath(ctx context.Context, from, to string, cutoff time.Time) (ratePath, error) {
+	var stale []string
+
+	direct, ok, err := s.pairRate(ctx, from, to)
+	if err != nil {
+		return ratePath{}, err
+	}
+
+	if ok {
+		if stale = direct.staleTitles(cutoff); len(stale) == 0 {
+			return direct, nil
+		}
+	}
+
+	pivots, err := s.pivotQuotes(ctx)
+	if err != nil {
+		return ratePath{}, err
+	}
+
+	for _, pivot := range pivots {
+		if pivot == from || pivot == to {
+			continue
+		}
+
+		first, ok, err := s.pairRate(ctx, from, pivot)
+		if err != nil {
+			return ratePath{}, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		second, ok, err := s.pairRate(ctx, pivot, to)
+		if err != nil {
+			return ratePath{}, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		path := ratePath{
+			num:  first.num.Mul(second.num),
+			den:  first.den.Mul(second.den),
+			legs: []*entities.Coin{first.legs[0], second.legs[0]},
+		}
+
+		staleLegs := path.staleTitles(cutoff)
+		if len(staleLegs) == 0 {
+			return path, nil
+		}
+
+		if stale == nil {
+			stale = staleLegs
+		}
+	}
+
+	if stale != nil {
+		return ratePath{}, entities.NewStaleRateError(stale, errors.Errorf("no rates newer than %s", cutoff.Format(time.RFC3339)))
+	}
+
+	return ratePath{}, entities.NewNotFoundError([]string{from, to})
+}
+
+// pairRate prices base in quote from the stored base/quote rate or, failing
+// that, from the inverse quote/base rate.
+func (s *Service) pairRate(ctx context.Context, base, quote string) (ratePath, bool, error) {
+	coins, err := s.Storage.GetActualCoin(ctx, []string{base}, quote)
+	if err != nil {
+		return ratePath{}, false, errors.Wrap(err, "failed to get actual coin")
+	}
+
+	if len(coins) > 0 {
+		return ratePath{num: coins[0].Cost, den: decimal.NewFromInt(1), legs: coins[:1]}, true, nil
+	}
+
+	coins, err = s.Storage.GetActualCoin(ctx, []string{quote}, base)
+	if err != nil {
+		return ratePath{}, false, errors.Wrap(err, "failed to get actual coin")
+	}
+
+	if len(coins) > 0 {
+		return ratePath{num: decimal.NewFromInt(1), den: coins[0].Cost, legs: coins[:1]}, true, nil
+	}
+
+	return ratePath{}, false, nil
+}
+
+// pivotQuotes returns the stored quotes with entities.DefaultQuote first.
+func (s *Service) pivotQuotes(ctx context.Context) ([]string, error) {
+	quotes, err := s.Storage.GetQuotesList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get quotes list")
+	}
+
+	pivots := make([]string, 0, len(quotes))
+
+	for _, quote := range quotes {
+		if quote == entities.DefaultQuote {
+			pivots = append([]string{quote}, pivots...)
+		} else {
+			pivots = append(pivots, quote)
+		}
+	}
+
+	return pivots, nil
+}
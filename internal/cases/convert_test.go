@@ -0,0 +1,260 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+// stubRates backs the storage mock with the latest rates in coins.
+func stubRates(mockStorage *mocks.MockStorage, coins []*entities.Coin) {
+	mockStorage.EXPECT().
+		GetActualCoin(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, titles []string, quote string) ([]*entities.Coin, error) {
+			var res []*entities.Coin
+
+			for _, coin := range coins {
+				if coin.Title == titles[0] && coin.Quote == quote {
+					res = append(res, coin)
+				}
+			}
+
+			return res, nil
+		}).
+		AnyTimes()
+
+	mockStorage.EXPECT().
+		GetQuotesList(gomock.Any()).
+		DoAndReturn(func(context.Context) ([]string, error) {
+			var quotes []string
+
+			seen := make(map[string]struct{})
+
+			for _, coin := range coins {
+				if _, ok := seen[coin.Quote]; !ok {
+					seen[coin.Quote] = struct{}{}
+					quotes = append(quotes, coin.Quote)
+				}
+			}
+
+			return quotes, nil
+		}).
+		AnyTimes()
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	old := now.Add(-time.Hour)
+
+	rate := func(title, quote string, cost string, actualAt time.Time) *entities.Coin {
+		return &entities.Coin{Title: title, Quote: quote, Cost: decimal.RequireFromString(cost), ActualAt: actualAt}
+	}
+
+	ethUSD := rate("ETH", "USD", "3000", now)
+	solUSD := rate("SOL", "USD", "150", now)
+	ethEUR := rate("ETH", "EUR", "2700", now)
+	btcEUR := rate("BTC", "EUR", "54000", now)
+
+	testTable := []struct {
+		name         string
+		from, to     string
+		amount       string
+		expectedFrom string
+		expectedTo   string
+		maxAge       time.Duration
+		rates        []*entities.Coin
+		expectedRes  string
+		expectedRate string
+		expectedLegs []*entities.Coin
+		expectedErr  error
+	}{
+		{
+			name:         "direct pair",
+			from:         "ETH",
+			to:           "EUR",
+			amount:       "2.5",
+			rates:        []*entities.Coin{ethUSD, ethEUR},
+			expectedRes:  "6750",
+			expectedRate: "2700",
+			expectedLegs: []*entities.Coin{ethEUR},
+		},
+		{
+			name:         "inverse pair",
+			from:         "EUR",
+			to:           "ETH",
+			amount:       "5400",
+			rates:        []*entities.Coin{ethEUR},
+			expectedRes:  "2",
+			expectedRate: "0.00037037037037037",
+			expectedLegs: []*entities.Coin{ethEUR},
+		},
+		{
+			name:         "triangulated through USD",
+			from:         "ETH",
+			to:           "SOL",
+			amount:       "2.5",
+			rates:        []*entities.Coin{ethUSD, solUSD},
+			expectedRes:  "50",
+			expectedRate: "20",
+			expectedLegs: []*entities.Coin{ethUSD, solUSD},
+		},
+		{
+			name:         "triangulated through another quote",
+			from:         "BTC",
+			to:           "ETH",
+			amount:       "1",
+			rates:        []*entities.Coin{solUSD, btcEUR, ethEUR},
+			expectedRes:  "20",
+			expectedRate: "20",
+			expectedLegs: []*entities.Coin{btcEUR, ethEUR},
+		},
+		{
+			name:         "stale direct pair, fresh triangulation",
+			from:         "ETH",
+			to:           "SOL",
+			amount:       "1",
+			rates:        []*entities.Coin{rate("ETH", "SOL", "25", old), ethUSD, solUSD},
+			expectedRes:  "20",
+			expectedRate: "20",
+			expectedLegs: []*entities.Coin{ethUSD, solUSD},
+		},
+		{
+			name:         "case-insensitive assets",
+			from:         " eth",
+			to:           "Eur",
+			amount:       "2.5",
+			rates:        []*entities.Coin{ethUSD, ethEUR},
+			expectedFrom: "ETH",
+			expectedTo:   "EUR",
+			expectedRes:  "6750",
+			expectedRate: "2700",
+			expectedLegs: []*entities.Coin{ethEUR},
+		},
+		{
+			name:         "same asset",
+			from:         "ETH",
+			to:           "eth",
+			amount:       "2.5",
+			rates:        []*entities.Coin{ethUSD},
+			expectedFrom: "ETH",
+			expectedTo:   "ETH",
+			expectedRes:  "2.5",
+			expectedRate: "1",
+		},
+		{
+			name:         "same quote",
+			from:         "EUR",
+			to:           "EUR",
+			amount:       "2.5",
+			rates:        []*entities.Coin{ethEUR},
+			expectedRes:  "2.5",
+			expectedRate: "1",
+		},
+		{
+			name:        "same unknown asset",
+			from:        "DOGE",
+			to:          "DOGE",
+			amount:      "1",
+			rates:       []*entities.Coin{ethUSD},
+			expectedErr: entities.ErrNotFound,
+		},
+		{
+			name:        "stale rates",
+			from:        "ETH",
+			to:          "SOL",
+			amount:      "1",
+			rates:       []*entities.Coin{rate("ETH", "USD", "3000", old), solUSD},
+			expectedErr: entities.ErrStaleRate,
+		},
+		{
+			name:         "max age",
+			from:         "ETH",
+			to:           "SOL",
+			amount:       "1",
+			maxAge:       2 * time.Hour,
+			rates:        []*entities.Coin{rate("ETH", "USD", "3000", old), solUSD},
+			expectedRes:  "20",
+			expectedRate: "20",
+			expectedLegs: []*entities.Coin{rate("ETH", "USD", "3000", old), solUSD},
+		},
+		{
+			name:        "no path",
+			from:        "ETH",
+			to:          "DOGE",
+			amount:      "1",
+			rates:       []*entities.Coin{ethUSD, btcEUR},
+			expectedErr: entities.ErrNotFound,
+		},
+		{
+			name:        "non-positive amount",
+			from:        "ETH",
+			to:          "SOL",
+			amount:      "0",
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "empty asset",
+			from:        "ETH",
+			amount:      "1",
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "negative max age",
+			from:        "ETH",
+			to:          "SOL",
+			amount:      "1",
+			maxAge:      -time.Minute,
+			expectedErr: entities.ErrInvalidParam,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorage(ctrl)
+			stubRates(mockStorage, tc.rates)
+
+			service := &cases.Service{Storage: mockStorage, Provider: mocks.NewMockCryptoProvider(ctrl)}
+
+			res, err := service.Convert(context.Background(), tc.from, tc.to, decimal.RequireFromString(tc.amount), tc.maxAge)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			if tc.expectedFrom == "" {
+				tc.expectedFrom, tc.expectedTo = tc.from, tc.to
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFrom, res.From)
+			require.Equal(t, tc.expectedTo, res.To)
+			require.Equal(t, tc.expectedRes, res.Result.String())
+			require.Equal(t, tc.expectedRate, res.Rate.String())
+			require.Equal(t, tc.expectedLegs, res.Legs)
+
+			// The oldest leg dates a conversion, an identity needs none and is current.
+			expectedAt := now
+
+			for _, leg := range tc.expectedLegs {
+				if leg.ActualAt.Before(expectedAt) {
+					expectedAt = leg.ActualAt
+				}
+			}
+
+			require.WithinDuration(t, expectedAt, res.ActualAt, time.Minute)
+		})
+	}
+}
@@ -8,5 +8,8 @@ import (
 
 //go:generate mockgen -source=crypto_provider.go -destination=mocks/crypto_provider_mock.go -package=mocks
 type CryptoProvider interface {
-	GetActualRates(ctx context.Context, titles []string) ([]*entities.Coin, error)
+	// GetActualRates returns the rates of titles in quote, an uppercase
+	// currency code such as USD or EUR. Titles it does not return are unknown
+	// to it, unless it fails: it may then still return the rates it got.
+	GetActualRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error)
 }
@@ -0,0 +1,15 @@
+package cases
+
+import (
+	"context"
+
+	"crypto-project/internal/entities"
+)
+
+// EventPublisher receives the events of the service. Publish must not block
+// on slow subscribers.
+//
+//go:generate mockgen -source=event_publisher.go -destination=mocks/event_publisher_mock.go -package=mocks
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entities.Event)
+}
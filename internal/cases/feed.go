@@ -0,0 +1,126 @@
+package cases
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+var (
+	// ErrSlowConsumer ends the subscriptions that fell a whole buffer behind.
+	ErrSlowConsumer = errors.New("slow consumer")
+	// ErrFeedClosed ends the subscriptions on shutdown.
+	ErrFeedClosed = errors.New("feed closed")
+)
+
+// Feed fans the published events out to in-process subscribers such as
+// streaming connections. Publish never blocks: a subscriber whose buffer is
+// full is dropped with ErrSlowConsumer.
+type Feed struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+var _ EventPublisher = (*Feed)(nil)
+
+func NewFeed() *Feed {
+	return &Feed{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives the events published after Subscribe, up to buffer of
+// them ahead of the reader.
+type Subscription struct {
+	feed   *Feed
+	events chan *entities.Event
+	err    error
+}
+
+// Subscribe starts a subscription. Subscribing to a closed feed returns an
+// already ended subscription.
+func (f *Feed) Subscribe(buffer int) *Subscription {
+	sub := &Subscription{feed: f, events: make(chan *entities.Event, max(buffer, 1))}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		sub.err = ErrFeedClosed
+		close(sub.events)
+
+		return sub
+	}
+
+	f.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+func (f *Feed) Publish(_ context.Context, event *entities.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for sub := range f.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			f.end(sub, ErrSlowConsumer)
+		}
+	}
+}
+
+// Close ends every subscription with ErrFeedClosed.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+
+	for sub := range f.subscribers {
+		f.end(sub, ErrFeedClosed)
+	}
+}
+
+// end must be called with f.mu held.
+func (f *Feed) end(sub *Subscription, err error) {
+	if _, ok := f.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(f.subscribers, sub)
+	sub.err = err
+	close(sub.events)
+}
+
+// Events is closed when the subscription ends, see Err.
+func (s *Subscription) Events() <-chan *entities.Event {
+	return s.events
+}
+
+// Err tells why the subscription ended once Events is closed: ErrSlowConsumer,
+// ErrFeedClosed or nil after Close.
+func (s *Subscription) Err() error {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+
+	s.feed.end(s, nil)
+}
+
+// EventPublishers publishes every event to each of its publishers in turn.
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(ctx context.Context, event *entities.Event) {
+	for _, publisher := range p {
+		publisher.Publish(ctx, event)
+	}
+}
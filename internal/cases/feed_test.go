@@ -0,0 +1,74 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestFeed(t *testing.T) {
+	t.Parallel()
+
+	feed := cases.NewFeed()
+	fast := feed.Subscribe(2)
+	slow := feed.Subscribe(1)
+	closed := feed.Subscribe(1)
+
+	closed.Close()
+
+	first := entities.NewRatesEvent(nil, time.Now())
+	second := entities.NewRatesEvent(nil, time.Now())
+
+	feed.Publish(context.Background(), first)
+	feed.Publish(context.Background(), second)
+
+	require.Equal(t, first, <-fast.Events())
+	require.Equal(t, second, <-fast.Events())
+
+	require.Equal(t, first, <-slow.Events())
+	_, ok := <-slow.Events()
+	require.False(t, ok)
+	require.ErrorIs(t, slow.Err(), cases.ErrSlowConsumer)
+
+	_, ok = <-closed.Events()
+	require.False(t, ok)
+	require.NoError(t, closed.Err())
+
+	feed.Close()
+
+	_, ok = <-fast.Events()
+	require.False(t, ok)
+	require.ErrorIs(t, fast.Err(), cases.ErrFeedClosed)
+
+	late := feed.Subscribe(1)
+	_, ok = <-late.Events()
+	require.False(t, ok)
+	require.ErrorIs(t, late.Err(), cases.ErrFeedClosed)
+
+	fast.Close()
+	feed.Publish(context.Background(), first)
+}
+
+func TestEventPublishers(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	first := mocks.NewMockEventPublisher(ctrl)
+	second := mocks.NewMockEventPublisher(ctrl)
+
+	event := entities.NewRatesEvent(nil, time.Now())
+
+	gomock.InOrder(
+		first.EXPECT().Publish(gomock.Any(), event),
+		second.EXPECT().Publish(gomock.Any(), event),
+	)
+
+	cases.EventPublishers{first, second}.Publish(context.Background(), event)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: alert_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=alert_storage.go -destination=mocks/alert_storage_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entities "crypto-project/internal/entities"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAlertStorage is a mock of AlertStorage interface.
+type MockAlertStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertStorageMockRecorder is the mock recorder for MockAlertStorage.
+type MockAlertStorageMockRecorder struct {
+	mock *MockAlertStorage
+}
+
+// NewMockAlertStorage creates a new mock instance.
+func NewMockAlertStorage(ctrl *gomock.Controller) *MockAlertStorage {
+	mock := &MockAlertStorage{ctrl: ctrl}
+	mock.recorder = &MockAlertStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertStorage) EXPECT() *MockAlertStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateAlertRule mocks base method.
+func (m *MockAlertStorage) CreateAlertRule(ctx context.Context, rule entities.AlertRule) (*entities.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlertRule", ctx, rule)
+	ret0, _ := ret[0].(*entities.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlertRule indicates an expected call of CreateAlertRule.
+func (mr *MockAlertStorageMockRecorder) CreateAlertRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlertRule", reflect.TypeOf((*MockAlertStorage)(nil).CreateAlertRule), ctx, rule)
+}
+
+// DeleteAlertRule mocks base method.
+func (m *MockAlertStorage) DeleteAlertRule(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlertRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlertRule indicates an expected call of DeleteAlertRule.
+func (mr *MockAlertStorageMockRecorder) DeleteAlertRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlertRule", reflect.TypeOf((*MockAlertStorage)(nil).DeleteAlertRule), ctx, id)
+}
+
+// GetAlertEvents mocks base method.
+func (m *MockAlertStorage) GetAlertEvents(ctx context.Context, ruleID int64, limit int) ([]*entities.AlertEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertEvents", ctx, ruleID, limit)
+	ret0, _ := ret[0].([]*entities.AlertEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertEvents indicates an expected call of GetAlertEvents.
+func (mr *MockAlertStorageMockRecorder) GetAlertEvents(ctx, ruleID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertEvents", reflect.TypeOf((*MockAlertStorage)(nil).GetAlertEvents), ctx, ruleID, limit)
+}
+
+// GetAlertRules mocks base method.
+func (m *MockAlertStorage) GetAlertRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertRules", ctx)
+	ret0, _ := ret[0].([]*entities.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertRules indicates an expected call of GetAlertRules.
+func (mr *MockAlertStorageMockRecorder) GetAlertRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertRules", reflect.TypeOf((*MockAlertStorage)(nil).GetAlertRules), ctx)
+}
+
+// SaveAlertEvaluation mocks base method.
+func (m *MockAlertStorage) SaveAlertEvaluation(ctx context.Context, rules []*entities.AlertRule, events []*entities.AlertEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAlertEvaluation", ctx, rules, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAlertEvaluation indicates an expected call of SaveAlertEvaluation.
+func (mr *MockAlertStorageMockRecorder) SaveAlertEvaluation(ctx, rules, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAlertEvaluation", reflect.TypeOf((*MockAlertStorage)(nil).SaveAlertEvaluation), ctx, rules, events)
+}
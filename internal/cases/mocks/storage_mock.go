@@ -13,6 +13,7 @@ import (
 	context "context"
 	entities "crypto-project/internal/entities"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,48 +43,108 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 }
 
 // GetActualCoin mocks base method.
-func (m *MockStorage) GetActualCoin(ctx context.Context, titles []string) ([]*entities.Coin, error) {
+func (m *MockStorage) GetActualCoin(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActualCoin", ctx, titles)
+	ret := m.ctrl.Call(m, "GetActualCoin", ctx, titles, quote)
 	ret0, _ := ret[0].([]*entities.Coin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetActualCoin indicates an expected call of GetActualCoin.
-func (mr *MockStorageMockRecorder) GetActualCoin(ctx, titles any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetActualCoin(ctx, titles, quote any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActualCoin", reflect.TypeOf((*MockStorage)(nil).GetActualCoin), ctx, titles)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActualCoin", reflect.TypeOf((*MockStorage)(nil).GetActualCoin), ctx, titles, quote)
 }
 
 // GetAggregateCoins mocks base method.
-func (m *MockStorage) GetAggregateCoins(ctx context.Context, titles []string, aggType string) ([]*entities.Coin, error) {
+func (m *MockStorage) GetAggregateCoins(ctx context.Context, titles []string, quote, aggType string, period entities.Period) ([]*entities.Coin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAggregateCoins", ctx, titles, aggType)
+	ret := m.ctrl.Call(m, "GetAggregateCoins", ctx, titles, quote, aggType, period)
 	ret0, _ := ret[0].([]*entities.Coin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAggregateCoins indicates an expected call of GetAggregateCoins.
-func (mr *MockStorageMockRecorder) GetAggregateCoins(ctx, titles, aggType any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetAggregateCoins(ctx, titles, quote, aggType, period any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateCoins", reflect.TypeOf((*MockStorage)(nil).GetAggregateCoins), ctx, titles, aggType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateCoins", reflect.TypeOf((*MockStorage)(nil).GetAggregateCoins), ctx, titles, quote, aggType, period)
+}
+
+// GetCandles mocks base method.
+func (m *MockStorage) GetCandles(ctx context.Context, titles []string, quote string, interval entities.Interval, period entities.Period) ([]*entities.Candle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandles", ctx, titles, quote, interval, period)
+	ret0, _ := ret[0].([]*entities.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockStorageMockRecorder) GetCandles(ctx, titles, quote, interval, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockStorage)(nil).GetCandles), ctx, titles, quote, interval, period)
 }
 
 // GetCoinsList mocks base method.
-func (m *MockStorage) GetCoinsList(ctx context.Context) ([]string, error) {
+func (m *MockStorage) GetCoinsList(ctx context.Context, quote string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCoinsList", ctx)
+	ret := m.ctrl.Call(m, "GetCoinsList", ctx, quote)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCoinsList indicates an expected call of GetCoinsList.
-func (mr *MockStorageMockRecorder) GetCoinsList(ctx any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetCoinsList(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsList", reflect.TypeOf((*MockStorage)(nil).GetCoinsList), ctx, quote)
+}
+
+// GetHistoricalCoin mocks base method.
+func (m *MockStorage) GetHistoricalCoin(ctx context.Context, titles []string, quote string, at time.Time) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalCoin", ctx, titles, quote, at)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalCoin indicates an expected call of GetHistoricalCoin.
+func (mr *MockStorageMockRecorder) GetHistoricalCoin(ctx, titles, quote, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalCoin", reflect.TypeOf((*MockStorage)(nil).GetHistoricalCoin), ctx, titles, quote, at)
+}
+
+// GetQuotesList mocks base method.
+func (m *MockStorage) GetQuotesList(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotesList", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotesList indicates an expected call of GetQuotesList.
+func (mr *MockStorageMockRecorder) GetQuotesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotesList", reflect.TypeOf((*MockStorage)(nil).GetQuotesList), ctx)
+}
+
+// GetRatesAfter mocks base method.
+func (m *MockStorage) GetRatesAfter(ctx context.Context, titles []string, quote string, after entities.RateCursor, limit int) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatesAfter", ctx, titles, quote, after, limit)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatesAfter indicates an expected call of GetRatesAfter.
+func (mr *MockStorageMockRecorder) GetRatesAfter(ctx, titles, quote, after, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsList", reflect.TypeOf((*MockStorage)(nil).GetCoinsList), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatesAfter", reflect.TypeOf((*MockStorage)(nil).GetRatesAfter), ctx, titles, quote, after, limit)
 }
 
 // Store mocks base method.
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_sender.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_sender.go -destination=mocks/webhook_sender_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entities "crypto-project/internal/entities"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Encode mocks base method.
+func (m *MockWebhookSender) Encode(event *entities.Event) (*entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encode", event)
+	ret0, _ := ret[0].(*entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encode indicates an expected call of Encode.
+func (mr *MockWebhookSenderMockRecorder) Encode(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*MockWebhookSender)(nil).Encode), event)
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, webhook *entities.Webhook, delivery *entities.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, webhook, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, webhook, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, webhook, delivery)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_storage.go -destination=mocks/webhook_storage_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entities "crypto-project/internal/entities"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookStorage is a mock of WebhookStorage interface.
+type MockWebhookStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookStorageMockRecorder is the mock recorder for MockWebhookStorage.
+type MockWebhookStorageMockRecorder struct {
+	mock *MockWebhookStorage
+}
+
+// NewMockWebhookStorage creates a new mock instance.
+func NewMockWebhookStorage(ctrl *gomock.Controller) *MockWebhookStorage {
+	mock := &MockWebhookStorage{ctrl: ctrl}
+	mock.recorder = &MockWebhookStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookStorage) EXPECT() *MockWebhookStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookStorage) CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(*entities.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookStorageMockRecorder) CreateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookStorage)(nil).CreateWebhook), ctx, webhook)
+}
+
+// DeleteDeadLetter mocks base method.
+func (m *MockWebhookStorage) DeleteDeadLetter(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadLetter", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeadLetter indicates an expected call of DeleteDeadLetter.
+func (mr *MockWebhookStorageMockRecorder) DeleteDeadLetter(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadLetter", reflect.TypeOf((*MockWebhookStorage)(nil).DeleteDeadLetter), ctx, id)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookStorage) DeleteWebhook(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookStorageMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookStorage)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetDeadLetter mocks base method.
+func (m *MockWebhookStorage) GetDeadLetter(ctx context.Context, id int64) (*entities.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", ctx, id)
+	ret0, _ := ret[0].(*entities.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockWebhookStorageMockRecorder) GetDeadLetter(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockWebhookStorage)(nil).GetDeadLetter), ctx, id)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockWebhookStorage) GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, limit)
+	ret0, _ := ret[0].([]*entities.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockWebhookStorageMockRecorder) GetDeadLetters(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockWebhookStorage)(nil).GetDeadLetters), ctx, limit)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhookStorage) GetWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx)
+	ret0, _ := ret[0].([]*entities.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookStorageMockRecorder) GetWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookStorage)(nil).GetWebhooks), ctx)
+}
+
+// SaveDeadLetter mocks base method.
+func (m *MockWebhookStorage) SaveDeadLetter(ctx context.Context, letter *entities.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeadLetter", ctx, letter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeadLetter indicates an expected call of SaveDeadLetter.
+func (mr *MockWebhookStorageMockRecorder) SaveDeadLetter(ctx, letter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeadLetter", reflect.TypeOf((*MockWebhookStorage)(nil).SaveDeadLetter), ctx, letter)
+}
@@ -0,0 +1,135 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"crypto-project/internal/entities"
+)
+
+// ChangeWindow is the period portfolio changes are reported over.
+const ChangeWindow = 24 * time.Hour
+
+// ChangeTolerance is how much older than the start of its window, ChangeWindow
+// or the window of an alert rule, the rate a change is measured from may be.
+// Changes from older rates are not reported.
+const ChangeTolerance = time.Hour
+
+// ValuePortfolio values holdings in quote at the latest stored rates and
+// reports how the value changed over ChangeWindow. Titles that are not stored
+// yet are fetched from the provider as by GetLastRates.
+func (s *Service) ValuePortfolio(
+	ctx context.Context,
+	holdings []entities.Holding,
+	quote string,
+) (*entities.Valuation, error) {
+	if len(holdings) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "holdings cannot be empty")
+	}
+
+	titles := make([]string, 0, len(holdings))
+	seen := make(map[string]struct{}, len(holdings))
+
+	for _, holding := range holdings {
+		if holding.Title == "" {
+			return nil, errors.Wrap(entities.ErrInvalidParam, "holding title cannot be empty")
+		}
+
+		if !holding.Quantity.IsPositive() {
+			return nil, errors.Wrapf(entities.ErrInvalidParam, "quantity of %s must be positive", holding.Title)
+		}
+
+		if _, ok := seen[holding.Title]; ok {
+			return nil, errors.Wrapf(entities.ErrInvalidParam, "duplicate holding of %s", holding.Title)
+		}
+
+		seen[holding.Title] = struct{}{}
+		titles = append(titles, holding.Title)
+	}
+
+	quote, err := entities.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.processNotExistingTitles(ctx, titles, quote); err != nil {
+		return nil, errors.Wrap(err, "failed to process not existing titles")
+	}
+
+	actualCoins, err := s.Storage.GetActualCoin(ctx, titles, quote)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get actual coin")
+	}
+
+	pastAt := time.Now().Add(-ChangeWindow)
+
+	pastCoins, err := s.Storage.GetHistoricalCoin(ctx, titles, quote, pastAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get historical coin")
+	}
+
+	actual := coinsByTitle(actualCoins)
+	past := coinsByTitle(pastCoins)
+
+	valuation := &entities.Valuation{
+		Quote:  quote,
+		Assets: make([]entities.AssetValuation, 0, len(holdings)),
+	}
+
+	pastTotal := decimal.Zero
+	complete := true
+
+	for _, holding := range holdings {
+		coin, ok := actual[holding.Title]
+		if !ok {
+			return nil, entities.NewNotFoundError([]string{holding.Title})
+		}
+
+		asset := entities.AssetValuation{
+			Title:    holding.Title,
+			Quantity: holding.Quantity,
+			Cost:     coin.Cost,
+			Value:    holding.Quantity.Mul(coin.Cost),
+			ActualAt: coin.ActualAt,
+		}
+
+		if pastCoin, ok := past[holding.Title]; ok && !pastCoin.ActualAt.Before(pastAt.Add(-ChangeTolerance)) {
+			asset.Change24h = relativeChange(pastCoin.Cost, coin.Cost)
+			pastTotal = pastTotal.Add(holding.Quantity.Mul(pastCoin.Cost))
+		} else {
+			complete = false
+		}
+
+		valuation.Total = valuation.Total.Add(asset.Value)
+		valuation.Assets = append(valuation.Assets, asset)
+	}
+
+	for i := range valuation.Assets {
+		valuation.Assets[i].Weight = valuation.Assets[i].Value.DivRound(valuation.Total, entities.PriceScale)
+	}
+
+	if complete {
+		valuation.Change24h = relativeChange(pastTotal, valuation.Total)
+	}
+
+	return valuation, nil
+}
+
+func coinsByTitle(coins []*entities.Coin) map[string]*entities.Coin {
+	byTitle := make(map[string]*entities.Coin, len(coins))
+
+	for _, coin := range coins {
+		byTitle[coin.Title] = coin
+	}
+
+	return byTitle
+}
+
+// relativeChange returns (to-from)/from.
+func relativeChange(from, to decimal.Decimal) *decimal.Decimal {
+	change := to.Sub(from).DivRound(from, entities.PriceScale)
+	return &change
+}
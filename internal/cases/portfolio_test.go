@@ -0,0 +1,189 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+func TestValuePortfolio(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	dayAgo := now.Add(-cases.ChangeWindow)
+
+	coin := func(title, cost string, actualAt time.Time) *entities.Coin {
+		return &entities.Coin{Title: title, Quote: "USD", Cost: decimal.RequireFromString(cost), ActualAt: actualAt}
+	}
+
+	holdings := []entities.Holding{
+		{Title: "ETH", Quantity: decimal.RequireFromString("2.5")},
+		{Title: "BTC", Quantity: decimal.RequireFromString("0.1")},
+	}
+
+	expectHistory := func(mockStorage *mocks.MockStorage, past []*entities.Coin) {
+		mockStorage.EXPECT().
+			GetCoinsList(gomock.Any(), "USD").
+			Return([]string{"BTC", "ETH"}, nil)
+		mockStorage.EXPECT().
+			GetActualCoin(gomock.Any(), []string{"ETH", "BTC"}, "USD").
+			Return([]*entities.Coin{coin("BTC", "60000", now), coin("ETH", "3000", now)}, nil)
+		mockStorage.EXPECT().
+			GetHistoricalCoin(gomock.Any(), []string{"ETH", "BTC"}, "USD", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ []string, _ string, at time.Time) ([]*entities.Coin, error) {
+				require.WithinDuration(t, dayAgo, at, time.Minute)
+				return past, nil
+			})
+	}
+
+	type asset struct {
+		title, value, weight, change string
+	}
+
+	testTable := []struct {
+		name           string
+		holdings       []entities.Holding
+		setupMock      func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider)
+		expectedTotal  string
+		expectedChange string
+		expectedAssets []asset
+		expectedErr    error
+	}{
+		{
+			name:     "valid holdings",
+			holdings: holdings,
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				expectHistory(mockStorage, []*entities.Coin{coin("BTC", "50000", dayAgo), coin("ETH", "3000", dayAgo)})
+			},
+			expectedTotal:  "13500",
+			expectedChange: "0.08",
+			expectedAssets: []asset{
+				{title: "ETH", value: "7500", weight: "0.555555555555555556", change: "0"},
+				{title: "BTC", value: "6000", weight: "0.444444444444444444", change: "0.2"},
+			},
+		},
+		{
+			name:     "incomplete history",
+			holdings: holdings,
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				expectHistory(mockStorage, []*entities.Coin{coin("BTC", "75000", dayAgo)})
+			},
+			expectedTotal: "13500",
+			expectedAssets: []asset{
+				{title: "ETH", value: "7500", weight: "0.555555555555555556"},
+				{title: "BTC", value: "6000", weight: "0.444444444444444444", change: "-0.2"},
+			},
+		},
+		{
+			name:     "outdated history",
+			holdings: holdings,
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				expectHistory(mockStorage, []*entities.Coin{
+					coin("BTC", "50000", dayAgo.Add(-cases.ChangeTolerance-time.Minute)),
+					coin("ETH", "2500", dayAgo.Add(-cases.ChangeTolerance+time.Minute)),
+				})
+			},
+			expectedTotal: "13500",
+			expectedAssets: []asset{
+				{title: "ETH", value: "7500", weight: "0.555555555555555556", change: "0.2"},
+				{title: "BTC", value: "6000", weight: "0.444444444444444444"},
+			},
+		},
+		{
+			name:     "unknown title",
+			holdings: []entities.Holding{{Title: "NOPE", Quantity: decimal.NewFromInt(1)}},
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"BTC"}, nil)
+				mockCryptoProvider.EXPECT().
+					GetActualRates(gomock.Any(), []string{"NOPE"}, "USD").
+					Return([]*entities.Coin{}, nil)
+				mockStorage.EXPECT().
+					Store(gomock.Any(), []*entities.Coin{}).
+					Return(nil)
+			},
+			expectedErr: entities.ErrNotFound,
+		},
+		{
+			name:     "storage error",
+			holdings: holdings,
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"BTC", "ETH"}, nil)
+				mockStorage.EXPECT().
+					GetActualCoin(gomock.Any(), []string{"ETH", "BTC"}, "USD").
+					Return(nil, entities.ErrStorage)
+			},
+			expectedErr: entities.ErrStorage,
+		},
+		{
+			name:        "empty holdings",
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "non-positive quantity",
+			holdings:    []entities.Holding{{Title: "BTC", Quantity: decimal.Zero}},
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "duplicate holding",
+			holdings:    append(holdings, holdings[0]),
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			expectedErr: entities.ErrInvalidParam,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockStorage(ctrl)
+			mockCryptoProvider := mocks.NewMockCryptoProvider(ctrl)
+			tc.setupMock(mockStorage, mockCryptoProvider)
+
+			service := &cases.Service{Storage: mockStorage, Provider: mockCryptoProvider}
+
+			res, err := service.ValuePortfolio(context.Background(), tc.holdings, "")
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "USD", res.Quote)
+			require.Equal(t, tc.expectedTotal, res.Total.String())
+
+			if tc.expectedChange == "" {
+				require.Nil(t, res.Change24h)
+			} else {
+				require.Equal(t, tc.expectedChange, res.Change24h.String())
+			}
+
+			require.Len(t, res.Assets, len(tc.expectedAssets))
+
+			for i, expected := range tc.expectedAssets {
+				actual := res.Assets[i]
+				require.Equal(t, expected.title, actual.Title)
+				require.Equal(t, expected.value, actual.Value.String(), actual.Title)
+				require.Equal(t, expected.weight, actual.Weight.String(), actual.Title)
+
+				if expected.change == "" {
+					require.Nil(t, actual.Change24h, actual.Title)
+				} else {
+					require.Equal(t, expected.change, actual.Change24h.String(), actual.Title)
+				}
+			}
+		})
+	}
+}
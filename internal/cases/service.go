@@ -2,6 +2,8 @@ package cases
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -11,6 +13,17 @@ import (
 type Service struct {
 	Provider CryptoProvider
 	Storage  Storage
+	// Alerts, if set, are evaluated after every ActualizeRates.
+	Alerts *Alerts
+	// Events, if set, is announced every stored batch of rates and every
+	// alert that fired or resolved.
+	Events EventPublisher
+	// OnActualized, if set, is called after every ActualizeRates with the
+	// number of rates it stored, also when it failed partway.
+	OnActualized func(stored int)
+
+	// storeMu keeps the rates announced to Events in the order of their Seq.
+	storeMu sync.Mutex
 }
 
 func NewService(provider CryptoProvider, storage Storage) (*Service, error) {
@@ -34,18 +47,31 @@ const (
 	AggTypeAvg = "avg"
 )
 
+// MaxCandles bounds the number of candles per title a single request can span.
+const MaxCandles = 1000
+
+// MaxRatesPage bounds the rates a single GetRatesAfter returns.
+const MaxRatesPage = 1000
+
 //TODO: COMMENTS IN CODE
 
-func (s *Service) GetLastRates(ctx context.Context, titles []string) ([]*entities.Coin, error) {
+// GetLastRates returns the latest rates of titles in quote. An empty quote
+// means entities.DefaultQuote, as for every other query.
+func (s *Service) GetLastRates(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error) {
 	if len(titles) == 0 {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty")
 	}
 
-	if err := s.processNotExistingTitles(ctx, titles); err != nil {
+	quote, err := entities.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.processNotExistingTitles(ctx, titles, quote); err != nil {
 		return nil, errors.Wrap(err, "failed to process not existing titles")
 	}
 
-	actualCoins, err := s.Storage.GetActualCoin(ctx, titles)
+	actualCoins, err := s.Storage.GetActualCoin(ctx, titles, quote)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get actual coin")
 	}
@@ -53,77 +79,216 @@ func (s *Service) GetLastRates(ctx context.Context, titles []string) ([]*entitie
 	return actualCoins, nil
 }
 
-func (s *Service) GetMaxRates(ctx context.Context, titles []string) ([]*entities.Coin, error) {
+// GetRatesAfter pages through the stored rates of titles in quote, ordered by
+// entities.RateCursor. Unlike the other queries it does not fetch unknown
+// titles: it reads the history only.
+func (s *Service) GetRatesAfter(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	after entities.RateCursor,
+	limit int,
+) ([]*entities.Coin, error) {
 	if len(titles) == 0 {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty")
 	}
 
-	if err := s.processNotExistingTitles(ctx, titles); err != nil {
-		return nil, errors.Wrap(err, "failed to process not existing titles")
+	if limit <= 0 || limit > MaxRatesPage {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", MaxRatesPage)
 	}
 
-	aggregateCoins, err := s.Storage.GetAggregateCoins(ctx, titles, AggTypeMax)
+	quote, err := entities.NormalizeQuote(quote)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get aggregate coins")
+		return nil, err
 	}
 
-	return aggregateCoins, nil
+	coins, err := s.Storage.GetRatesAfter(ctx, titles, quote, after, limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rates after %s", after)
+	}
+
+	return coins, nil
 }
 
-func (s *Service) GetMinRates(ctx context.Context, titles []string) ([]*entities.Coin, error) {
+func (s *Service) GetMaxRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return s.getAggregateRates(ctx, titles, quote, AggTypeMax, period)
+}
+
+func (s *Service) GetMinRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return s.getAggregateRates(ctx, titles, quote, AggTypeMin, period)
+}
+
+func (s *Service) GetAvgRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	return s.getAggregateRates(ctx, titles, quote, AggTypeAvg, period)
+}
+
+// GetCandles returns the candles of titles within period. The period must have
+// a start, an open end means up to now.
+func (s *Service) GetCandles(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	interval entities.Interval,
+	period entities.Period,
+) ([]*entities.Candle, error) {
 	if len(titles) == 0 {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty")
 	}
 
-	if err := s.processNotExistingTitles(ctx, titles); err != nil {
-		return nil, errors.Wrap(err, "failed to process not existing titles")
+	if err := interval.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid interval")
 	}
 
-	aggregateCoins, err := s.Storage.GetAggregateCoins(ctx, titles, AggTypeMin)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get aggregate coins")
+	if err := period.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid period")
 	}
 
-	return aggregateCoins, nil
-}
+	if period.From.IsZero() {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "candles period must have a start")
+	}
 
-func (s *Service) GetAvgRates(ctx context.Context, titles []string) ([]*entities.Coin, error) {
-	if len(titles) == 0 {
-		return nil, errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty")
+	to := period.To
+	if to.IsZero() {
+		to = time.Now()
 	}
 
-	if err := s.processNotExistingTitles(ctx, titles); err != nil {
+	if to.Sub(period.From)/time.Duration(interval) >= MaxCandles {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "period spans more than %d %s candles", MaxCandles, interval)
+	}
+
+	quote, err := entities.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.processNotExistingTitles(ctx, titles, quote); err != nil {
 		return nil, errors.Wrap(err, "failed to process not existing titles")
 	}
 
-	aggregateCoins, err := s.Storage.GetAggregateCoins(ctx, titles, AggTypeAvg)
+	candles, err := s.Storage.GetCandles(ctx, titles, quote, interval, period)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get aggregate coins")
+		return nil, errors.Wrap(err, "failed to get candles")
 	}
 
-	return aggregateCoins, nil
+	return candles, nil
 }
 
+// ActualizeRates fetches fresh rates of every stored title in every stored
+// quote, then evaluates the alert rules against them.
 func (s *Service) ActualizeRates(ctx context.Context) error {
-	listCoins, err := s.Storage.GetCoinsList(ctx)
+	stored := 0
+
+	if s.OnActualized != nil {
+		defer func() { s.OnActualized(stored) }()
+	}
+
+	quotes, err := s.Storage.GetQuotesList(ctx)
 	if err != nil {
-		return errors.Wrap(err, "failed to get coins list")
+		return errors.Wrap(err, "failed to get quotes list")
+	}
+
+	for _, quote := range quotes {
+		listCoins, err := s.Storage.GetCoinsList(ctx, quote)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s coins list", quote)
+		}
+
+		// The rates served despite a failure are still actualized.
+		actualRatesCoins, err := s.Provider.GetActualRates(ctx, listCoins, quote)
+		if err != nil && len(actualRatesCoins) == 0 {
+			return errors.Wrapf(err, "failed to get actual %s rates", quote)
+		}
+
+		if err = s.storeRates(ctx, actualRatesCoins); err != nil {
+			return errors.Wrapf(err, "failed to store %s coins", quote)
+		}
+
+		stored += len(actualRatesCoins)
 	}
 
-	actualRatesCoins, err := s.Provider.GetActualRates(ctx, listCoins)
+	if s.Alerts == nil {
+		return nil
+	}
+
+	alertEvents, err := s.Alerts.Evaluate(ctx)
 	if err != nil {
-		return errors.Wrap(err, "failed to get actual rates")
+		return errors.Wrap(err, "failed to evaluate alerts")
 	}
 
-	if err = s.Storage.Store(ctx, actualRatesCoins); err != nil {
-		return errors.Wrap(err, "failed to store coins")
+	if s.Events != nil {
+		for _, alertEvent := range alertEvents {
+			s.Events.Publish(ctx, entities.NewAlertEvent(alertEvent))
+		}
+	}
+
+	return nil
+}
+
+// storeRates stores coins and announces them to s.Events.
+func (s *Service) storeRates(ctx context.Context, coins []*entities.Coin) error {
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+
+	if err := s.Storage.Store(ctx, coins); err != nil {
+		return err
+	}
+
+	if s.Events != nil && len(coins) > 0 {
+		s.Events.Publish(ctx, entities.NewRatesEvent(coins, time.Now()))
 	}
 
 	return nil
 }
 
-func (s *Service) processNotExistingTitles(ctx context.Context, titles []string) error {
-	storedCoins, err := s.Storage.GetCoinsList(ctx)
+func (s *Service) getAggregateRates(
+	ctx context.Context,
+	titles []string,
+	quote string,
+	aggType string,
+	period entities.Period,
+) ([]*entities.Coin, error) {
+	if len(titles) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "titles cannot be empty")
+	}
+
+	if err := period.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid period")
+	}
+
+	quote, err := entities.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.processNotExistingTitles(ctx, titles, quote); err != nil {
+		return nil, errors.Wrap(err, "failed to process not existing titles")
+	}
+
+	aggregateCoins, err := s.Storage.GetAggregateCoins(ctx, titles, quote, aggType, period)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get aggregate coins")
+	}
+
+	return aggregateCoins, nil
+}
+
+func (s *Service) processNotExistingTitles(ctx context.Context, titles []string, quote string) error {
+	storedCoins, err := s.Storage.GetCoinsList(ctx, quote)
 	if err != nil {
 		return errors.Wrap(err, "failed to get coins list")
 	}
@@ -146,14 +311,37 @@ func (s *Service) processNotExistingTitles(ctx context.Context, titles []string)
 		return nil
 	}
 
-	coins, err := s.Provider.GetActualRates(ctx, notStoredCoins)
-	if err != nil {
-		return errors.Wrap(err, "failed to get actual rates")
+	coins, providerErr := s.Provider.GetActualRates(ctx, notStoredCoins, quote)
+	if providerErr != nil && len(coins) == 0 {
+		return errors.Wrap(providerErr, "failed to get actual rates")
 	}
 
-	if err = s.Storage.Store(ctx, coins); err != nil {
+	if err = s.storeRates(ctx, coins); err != nil {
 		return errors.Wrap(err, "failed to store coins")
 	}
 
+	// The titles that were not served may be held by the provider that failed.
+	if providerErr != nil {
+		return errors.Wrap(providerErr, "failed to get actual rates")
+	}
+
+	fetchedTitles := make(map[string]struct{}, len(coins))
+
+	for _, coin := range coins {
+		fetchedTitles[coin.Title] = struct{}{}
+	}
+
+	unknownTitles := make([]string, 0)
+
+	for _, title := range notStoredCoins {
+		if _, ok := fetchedTitles[title]; !ok {
+			unknownTitles = append(unknownTitles, title)
+		}
+	}
+
+	if len(unknownTitles) > 0 {
+		return entities.NewNotFoundError(unknownTitles)
+	}
+
 	return nil
 }
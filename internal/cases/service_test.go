@@ -3,8 +3,10 @@ package cases_test
 import (
 	"context"
 	"crypto-project/internal/entities"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 
@@ -29,6 +31,7 @@ func TestAggregateFunctions(t *testing.T) {
 	testTable := []struct {
 		name        string
 		titles      []string
+		period      entities.Period
 		setupMock   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider)
 		expectedRes []*entities.Coin
 		wantErr     bool
@@ -39,22 +42,22 @@ func TestAggregateFunctions(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "ETC", "TON"}, nil).
 					Times(3)
 				mockStorage.EXPECT().
-					GetAggregateCoins(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, gomock.Any()).
+					GetAggregateCoins(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, "USD", gomock.Any(), entities.Period{}).
 					Return([]*entities.Coin{
-						{Title: "Bitcoin", Cost: 1000},
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil).
 					Times(3)
 			},
 			expectedRes: []*entities.Coin{
-				{Title: "Bitcoin", Cost: 1000},
-				{Title: "ETH", Cost: 5555},
-				{Title: "TON", Cost: 1},
+				{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+				{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+				{Title: "TON", Cost: decimal.NewFromInt(1)},
 			},
 			wantErr: false,
 		},
@@ -63,36 +66,36 @@ func TestAggregateFunctions(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin"}, nil).
 					Times(3)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"ETC", "TON"}).
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
 					Return([]*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil).
 					Times(3)
 				mockStorage.EXPECT().
 					Store(gomock.Any(), []*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}).
 					Return(nil).
 					Times(3)
 				mockStorage.EXPECT().
-					GetAggregateCoins(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, gomock.Any()).
+					GetAggregateCoins(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, "USD", gomock.Any(), entities.Period{}).
 					Return([]*entities.Coin{
-						{Title: "Bitcoin", Cost: 1000},
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil).
 					Times(3)
 			},
 			expectedRes: []*entities.Coin{
-				{Title: "Bitcoin", Cost: 1000},
-				{Title: "ETH", Cost: 5555},
-				{Title: "TON", Cost: 1},
+				{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+				{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+				{Title: "TON", Cost: decimal.NewFromInt(1)},
 			},
 			wantErr: false,
 		},
@@ -101,7 +104,7 @@ func TestAggregateFunctions(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return(nil, entities.ErrStorage).
 					Times(3)
 			},
@@ -114,11 +117,11 @@ func TestAggregateFunctions(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin"}, nil).
 					Times(3)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"ETC", "TON"}).
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
 					Return(nil, entities.ErrProvider).
 					Times(3)
 			},
@@ -131,20 +134,20 @@ func TestAggregateFunctions(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin"}, nil).
 					Times(3)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"ETC", "TON"}).
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
 					Return([]*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil).
 					Times(3)
 				mockStorage.EXPECT().
 					Store(gomock.Any(), []*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}).
 					Return(entities.ErrStorage).
 					Times(3)
@@ -158,11 +161,11 @@ func TestAggregateFunctions(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "ETC", "TON"}, nil).
 					Times(3)
 				mockStorage.EXPECT().
-					GetAggregateCoins(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, gomock.Any()).
+					GetAggregateCoins(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, "USD", gomock.Any(), entities.Period{}).
 					Return(nil, entities.ErrStorage).
 					Times(3)
 			},
@@ -170,6 +173,43 @@ func TestAggregateFunctions(t *testing.T) {
 			wantErr:     true,
 			expectedErr: entities.ErrStorage,
 		},
+		{
+			name:   "valid params, unknown title",
+			titles: []string{"Bitcoin", "ETC", "TON"},
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"Bitcoin"}, nil).
+					Times(3)
+				mockCryptoProvider.EXPECT().
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
+					Return([]*entities.Coin{
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+					}, nil).
+					Times(3)
+				mockStorage.EXPECT().
+					Store(gomock.Any(), []*entities.Coin{
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+					}).
+					Return(nil).
+					Times(3)
+			},
+			expectedRes: nil,
+			wantErr:     true,
+			expectedErr: entities.ErrNotFound,
+		},
+		{
+			name:   "invalid period",
+			titles: []string{"Bitcoin", "ETC", "TON"},
+			period: entities.Period{
+				From: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			expectedRes: nil,
+			wantErr:     true,
+			expectedErr: entities.ErrInvalidParam,
+		},
 		{
 			name:        "empty titles",
 			titles:      []string{},
@@ -185,9 +225,9 @@ func TestAggregateFunctions(t *testing.T) {
 
 			tc.setupMock(mockStorage, mockCryptoProvider)
 
-			maxCoins, errMax := service.GetMaxRates(context.Background(), tc.titles)
-			minCoins, errMin := service.GetMinRates(context.Background(), tc.titles)
-			avgCoins, errAvg := service.GetAvgRates(context.Background(), tc.titles)
+			maxCoins, errMax := service.GetMaxRates(context.Background(), tc.titles, "", tc.period)
+			minCoins, errMin := service.GetMinRates(context.Background(), tc.titles, "", tc.period)
+			avgCoins, errAvg := service.GetAvgRates(context.Background(), tc.titles, "", tc.period)
 
 			if tc.wantErr {
 				require.ErrorIs(t, errMax, tc.expectedErr)
@@ -227,6 +267,7 @@ func TestGetLastRates(t *testing.T) {
 	testTable := []struct {
 		name        string
 		titles      []string
+		quote       string
 		setupMock   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider)
 		expectedRes []*entities.Coin
 		wantErr     bool
@@ -237,20 +278,20 @@ func TestGetLastRates(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "ETC", "TON"}, nil)
 				mockStorage.EXPECT().
-					GetActualCoin(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}).
+					GetActualCoin(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, "USD").
 					Return([]*entities.Coin{
-						{Title: "Bitcoin", Cost: 1000},
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil)
 			},
 			expectedRes: []*entities.Coin{
-				{Title: "Bitcoin", Cost: 1000},
-				{Title: "ETH", Cost: 5555},
-				{Title: "TON", Cost: 1},
+				{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+				{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+				{Title: "TON", Cost: decimal.NewFromInt(1)},
 			},
 			wantErr: false,
 		},
@@ -259,32 +300,32 @@ func TestGetLastRates(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin"}, nil)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"ETC", "TON"}).
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
 					Return([]*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil)
 				mockStorage.EXPECT().
 					Store(gomock.Any(), []*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}).
 					Return(nil)
 				mockStorage.EXPECT().
-					GetActualCoin(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}).
+					GetActualCoin(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, "USD").
 					Return([]*entities.Coin{
-						{Title: "Bitcoin", Cost: 1000},
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil)
 			},
 			expectedRes: []*entities.Coin{
-				{Title: "Bitcoin", Cost: 1000},
-				{Title: "ETH", Cost: 5555},
-				{Title: "TON", Cost: 1},
+				{Title: "Bitcoin", Cost: decimal.NewFromInt(1000)},
+				{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+				{Title: "TON", Cost: decimal.NewFromInt(1)},
 			},
 			wantErr: false,
 		},
@@ -293,7 +334,7 @@ func TestGetLastRates(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return(nil, entities.ErrStorage)
 			},
 			expectedRes: nil,
@@ -305,10 +346,10 @@ func TestGetLastRates(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin"}, nil)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"ETC", "TON"}).
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
 					Return(nil, entities.ErrProvider)
 			},
 			expectedRes: nil,
@@ -320,18 +361,18 @@ func TestGetLastRates(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin"}, nil)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"ETC", "TON"}).
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
 					Return([]*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}, nil)
 				mockStorage.EXPECT().
 					Store(gomock.Any(), []*entities.Coin{
-						{Title: "ETH", Cost: 5555},
-						{Title: "TON", Cost: 1},
+						{Title: "ETH", Cost: decimal.NewFromInt(5555)},
+						{Title: "TON", Cost: decimal.NewFromInt(1)},
 					}).
 					Return(entities.ErrStorage)
 			},
@@ -344,16 +385,82 @@ func TestGetLastRates(t *testing.T) {
 			titles: []string{"Bitcoin", "ETC", "TON"},
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "ETC", "TON"}, nil)
 				mockStorage.EXPECT().
-					GetActualCoin(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}).
+					GetActualCoin(gomock.Any(), []string{"Bitcoin", "ETC", "TON"}, "USD").
 					Return(nil, entities.ErrStorage)
 			},
 			expectedRes: nil,
 			wantErr:     true,
 			expectedErr: entities.ErrStorage,
 		},
+		{
+			name:   "valid params, unknown title",
+			titles: []string{"Bitcoin", "ETC", "TON"},
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"Bitcoin"}, nil)
+				mockCryptoProvider.EXPECT().
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
+					Return([]*entities.Coin{
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+					}, nil)
+				mockStorage.EXPECT().
+					Store(gomock.Any(), []*entities.Coin{
+						{Title: "ETC", Cost: decimal.NewFromInt(5555)},
+					}).
+					Return(nil)
+			},
+			expectedRes: nil,
+			wantErr:     true,
+			expectedErr: entities.ErrNotFound,
+		},
+		{
+			name:   "valid params, provider failed for some titles",
+			titles: []string{"Bitcoin", "ETC", "TON"},
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"Bitcoin"}, nil)
+				mockCryptoProvider.EXPECT().
+					GetActualRates(gomock.Any(), []string{"ETC", "TON"}, "USD").
+					Return([]*entities.Coin{{Title: "ETC", Cost: decimal.NewFromInt(5555)}},
+						entities.NewProviderError("fallback", []string{"TON"}, true, entities.ErrProvider))
+				// The served rates are kept, TON is unavailable rather than unknown.
+				mockStorage.EXPECT().
+					Store(gomock.Any(), []*entities.Coin{{Title: "ETC", Cost: decimal.NewFromInt(5555)}}).
+					Return(nil)
+			},
+			expectedRes: nil,
+			wantErr:     true,
+			expectedErr: entities.ErrProvider,
+		},
+		{
+			name:   "valid params, requested quote",
+			titles: []string{"Bitcoin"},
+			quote:  "eur",
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "EUR").
+					Return([]string{"Bitcoin"}, nil)
+				mockStorage.EXPECT().
+					GetActualCoin(gomock.Any(), []string{"Bitcoin"}, "EUR").
+					Return([]*entities.Coin{{Title: "Bitcoin", Quote: "EUR", Cost: decimal.NewFromInt(900)}}, nil)
+			},
+			expectedRes: []*entities.Coin{{Title: "Bitcoin", Quote: "EUR", Cost: decimal.NewFromInt(900)}},
+			wantErr:     false,
+		},
+		{
+			name:        "invalid quote",
+			titles:      []string{"Bitcoin"},
+			quote:       "E/R",
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			expectedRes: nil,
+			wantErr:     true,
+			expectedErr: entities.ErrInvalidParam,
+		},
 		{
 			name:        "empty titles",
 			titles:      []string{},
@@ -368,7 +475,7 @@ func TestGetLastRates(t *testing.T) {
 
 			tc.setupMock(mockStorage, mockCryptoProvider)
 
-			coins, err := service.GetLastRates(context.Background(), tc.titles)
+			coins, err := service.GetLastRates(context.Background(), tc.titles, tc.quote)
 
 			if tc.wantErr {
 				require.ErrorIs(t, err, tc.expectedErr)
@@ -406,10 +513,13 @@ func TestActualizeRates(t *testing.T) {
 			name: "valid params",
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetQuotesList(gomock.Any()).
+					Return([]string{"USD"}, nil)
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "TON", "ETH"}, nil)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"Bitcoin", "TON", "ETH"}).
+					GetActualRates(gomock.Any(), []string{"Bitcoin", "TON", "ETH"}, "USD").
 					Return([]*entities.Coin{
 						{Title: "Bitcoin"},
 						{Title: "TON"},
@@ -430,7 +540,10 @@ func TestActualizeRates(t *testing.T) {
 			name: "response GetCoinsList with error",
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetQuotesList(gomock.Any()).
+					Return([]string{"USD"}, nil)
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
 					Return(nil, entities.ErrStorage)
 			},
 			wantErr:     true,
@@ -440,10 +553,13 @@ func TestActualizeRates(t *testing.T) {
 			name: "response GetActualRates with error",
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetQuotesList(gomock.Any()).
+					Return([]string{"USD"}, nil)
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "TON", "ETH"}, nil)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"Bitcoin", "TON", "ETH"}).
+					GetActualRates(gomock.Any(), []string{"Bitcoin", "TON", "ETH"}, "USD").
 					Return(nil, entities.ErrStorage)
 			},
 			wantErr:     true,
@@ -453,10 +569,13 @@ func TestActualizeRates(t *testing.T) {
 			name: "response Store with error",
 			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
 				mockStorage.EXPECT().
-					GetCoinsList(gomock.Any()).
+					GetQuotesList(gomock.Any()).
+					Return([]string{"USD"}, nil)
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
 					Return([]string{"Bitcoin", "TON", "ETH"}, nil)
 				mockCryptoProvider.EXPECT().
-					GetActualRates(gomock.Any(), []string{"Bitcoin", "TON", "ETH"}).
+					GetActualRates(gomock.Any(), []string{"Bitcoin", "TON", "ETH"}, "USD").
 					Return([]*entities.Coin{
 						{Title: "Bitcoin"},
 						{Title: "TON"},
@@ -490,6 +609,73 @@ func TestActualizeRates(t *testing.T) {
 	}
 }
 
+func TestActualizeRatesQuotes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockCryptoProvider := mocks.NewMockCryptoProvider(ctrl)
+
+	var stored []int
+
+	service := &cases.Service{
+		Storage:      mockStorage,
+		Provider:     mockCryptoProvider,
+		OnActualized: func(n int) { stored = append(stored, n) },
+	}
+
+	eurCoins := []*entities.Coin{{Title: "Bitcoin", Quote: "EUR", Cost: decimal.NewFromInt(900)}}
+	usdCoins := []*entities.Coin{{Title: "Bitcoin", Quote: "USD", Cost: decimal.NewFromInt(1000)}}
+
+	gomock.InOrder(
+		mockStorage.EXPECT().GetQuotesList(gomock.Any()).Return([]string{"EUR", "USD"}, nil),
+		mockStorage.EXPECT().GetCoinsList(gomock.Any(), "EUR").Return([]string{"Bitcoin"}, nil),
+		mockCryptoProvider.EXPECT().GetActualRates(gomock.Any(), []string{"Bitcoin"}, "EUR").Return(eurCoins, nil),
+		mockStorage.EXPECT().Store(gomock.Any(), eurCoins).Return(nil),
+		mockStorage.EXPECT().GetCoinsList(gomock.Any(), "USD").Return([]string{"Bitcoin"}, nil),
+		mockCryptoProvider.EXPECT().GetActualRates(gomock.Any(), []string{"Bitcoin"}, "USD").Return(usdCoins, nil),
+		mockStorage.EXPECT().Store(gomock.Any(), usdCoins).Return(nil),
+	)
+
+	require.NoError(t, service.ActualizeRates(context.Background()))
+
+	mockStorage.EXPECT().GetQuotesList(gomock.Any()).Return(nil, entities.ErrStorage)
+
+	require.ErrorIs(t, service.ActualizeRates(context.Background()), entities.ErrStorage)
+	require.Equal(t, []int{2, 0}, stored)
+}
+
+func TestGetRatesAfter(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+
+	service := &cases.Service{
+		Storage:  mockStorage,
+		Provider: mocks.NewMockCryptoProvider(ctrl),
+	}
+
+	after := entities.RateCursor{Seq: 42}
+	coins := []*entities.Coin{{Title: "ETH", Quote: "EUR", Cost: decimal.NewFromInt(10), ActualAt: time.Now(), Seq: 43}}
+
+	mockStorage.EXPECT().
+		GetRatesAfter(gomock.Any(), []string{"BTC", "ETH"}, "EUR", after, 10).
+		Return(coins, nil)
+
+	res, err := service.GetRatesAfter(context.Background(), []string{"BTC", "ETH"}, "eur", after, 10)
+	require.NoError(t, err)
+	require.Equal(t, coins, res)
+
+	_, err = service.GetRatesAfter(context.Background(), nil, "", after, 10)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = service.GetRatesAfter(context.Background(), []string{"BTC"}, "", after, cases.MaxRatesPage+1)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
 func TestNewService(t *testing.T) {
 	t.Parallel()
 
@@ -579,3 +765,121 @@ func TestNewService(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCandles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockCryptoProvider := mocks.NewMockCryptoProvider(ctrl)
+
+	service := &cases.Service{
+		Storage:  mockStorage,
+		Provider: mockCryptoProvider,
+	}
+
+	now := time.Now()
+	lastHour := entities.Period{From: now.Add(-time.Hour), To: now}
+
+	candles := []*entities.Candle{
+		{Title: "BTC", Interval: entities.Interval5m, Start: now.Add(-time.Hour), Open: decimal.NewFromInt(1), High: decimal.NewFromInt(3), Low: decimal.NewFromInt(1), Close: decimal.NewFromInt(2)},
+	}
+
+	testTable := []struct {
+		name        string
+		titles      []string
+		interval    entities.Interval
+		period      entities.Period
+		setupMock   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider)
+		expectedRes []*entities.Candle
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:     "valid params",
+			titles:   []string{"BTC"},
+			interval: entities.Interval5m,
+			period:   lastHour,
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"BTC"}, nil)
+				mockStorage.EXPECT().
+					GetCandles(gomock.Any(), []string{"BTC"}, "USD", entities.Interval5m, lastHour).
+					Return(candles, nil)
+			},
+			expectedRes: candles,
+		},
+		{
+			name:     "valid params, storage error",
+			titles:   []string{"BTC"},
+			interval: entities.Interval5m,
+			period:   lastHour,
+			setupMock: func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {
+				mockStorage.EXPECT().
+					GetCoinsList(gomock.Any(), "USD").
+					Return([]string{"BTC"}, nil)
+				mockStorage.EXPECT().
+					GetCandles(gomock.Any(), []string{"BTC"}, "USD", entities.Interval5m, lastHour).
+					Return(nil, entities.ErrStorage)
+			},
+			wantErr:     true,
+			expectedErr: entities.ErrStorage,
+		},
+		{
+			name:        "empty titles",
+			titles:      []string{},
+			interval:    entities.Interval5m,
+			period:      lastHour,
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			wantErr:     true,
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "unsupported interval",
+			titles:      []string{"BTC"},
+			interval:    entities.Interval(time.Second),
+			period:      lastHour,
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			wantErr:     true,
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "period without start",
+			titles:      []string{"BTC"},
+			interval:    entities.Interval5m,
+			period:      entities.Period{To: now},
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			wantErr:     true,
+			expectedErr: entities.ErrInvalidParam,
+		},
+		{
+			name:        "too many candles",
+			titles:      []string{"BTC"},
+			interval:    entities.Interval1m,
+			period:      entities.Period{From: now.Add(-cases.MaxCandles * time.Minute)},
+			setupMock:   func(mockStorage *mocks.MockStorage, mockCryptoProvider *mocks.MockCryptoProvider) {},
+			wantErr:     true,
+			expectedErr: entities.ErrInvalidParam,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock(mockStorage, mockCryptoProvider)
+
+			res, err := service.GetCandles(context.Background(), tc.titles, "USD", tc.interval, tc.period)
+
+			if tc.wantErr {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRes, res)
+		})
+	}
+}
@@ -2,14 +2,49 @@ package cases
 
 import (
 	"context"
+	"time"
 
 	"crypto-project/internal/entities"
 )
 
+// Storage keeps the history of coin rates. Every rate is keyed by its title
+// and quote currency, and queries only see the rates of the quote they ask for.
+//
 //go:generate mockgen -source=storage.go -destination=mocks/storage_mock.go -package=mocks
 type Storage interface {
+	// Store stores coins in order and sets their Seq. A coin becomes readable
+	// only with or after every coin of a lower Seq.
 	Store(ctx context.Context, coins []*entities.Coin) error
-	GetCoinsList(ctx context.Context) ([]string, error)
-	GetActualCoin(ctx context.Context, titles []string) ([]*entities.Coin, error)
-	GetAggregateCoins(ctx context.Context, titles []string, aggType string) ([]*entities.Coin, error)
+	// GetQuotesList returns every quote currency rates are stored in.
+	GetQuotesList(ctx context.Context) ([]string, error)
+	GetCoinsList(ctx context.Context, quote string) ([]string, error)
+	GetActualCoin(ctx context.Context, titles []string, quote string) ([]*entities.Coin, error)
+	// GetHistoricalCoin returns the latest rate of every title actual at or
+	// before at. Titles without such a rate are skipped.
+	GetHistoricalCoin(ctx context.Context, titles []string, quote string, at time.Time) ([]*entities.Coin, error)
+	// GetRatesAfter returns up to limit rates of titles stored after the one
+	// at after, ordered by Seq. The zero cursor starts from the beginning.
+	GetRatesAfter(
+		ctx context.Context,
+		titles []string,
+		quote string,
+		after entities.RateCursor,
+		limit int,
+	) ([]*entities.Coin, error)
+	GetAggregateCoins(
+		ctx context.Context,
+		titles []string,
+		quote string,
+		aggType string,
+		period entities.Period,
+	) ([]*entities.Coin, error)
+	// GetCandles builds the candles of every title within period, ordered by
+	// title and start. Intervals without stored rates have no candle.
+	GetCandles(
+		ctx context.Context,
+		titles []string,
+		quote string,
+		interval entities.Interval,
+		period entities.Period,
+	) ([]*entities.Candle, error)
 }
@@ -0,0 +1,17 @@
+package cases
+
+import (
+	"context"
+
+	"crypto-project/internal/entities"
+)
+
+// WebhookSender encodes events and delivers them to webhooks.
+//
+//go:generate mockgen -source=webhook_sender.go -destination=mocks/webhook_sender_mock.go -package=mocks
+type WebhookSender interface {
+	Encode(event *entities.Event) (*entities.Delivery, error)
+	// Send makes a single delivery attempt. Failures are entities.ErrDelivery,
+	// retryable when another attempt may succeed.
+	Send(ctx context.Context, webhook *entities.Webhook, delivery *entities.Delivery) error
+}
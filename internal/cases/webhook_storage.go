@@ -0,0 +1,28 @@
+package cases
+
+import (
+	"context"
+
+	"crypto-project/internal/entities"
+)
+
+// WebhookStorage keeps the registered webhooks and their dead letters.
+//
+//go:generate mockgen -source=webhook_storage.go -destination=mocks/webhook_storage_mock.go -package=mocks
+type WebhookStorage interface {
+	// CreateWebhook stores webhook and returns it with its ID assigned.
+	CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error)
+	// GetWebhooks returns every webhook ordered by ID.
+	GetWebhooks(ctx context.Context) ([]*entities.Webhook, error)
+	// DeleteWebhook deletes a webhook and its dead letters. It returns
+	// entities.ErrNotFound for an unknown id.
+	DeleteWebhook(ctx context.Context, id int64) error
+	// SaveDeadLetter inserts a letter without ID, assigning it, and updates
+	// one with ID. Letters of deleted webhooks are dropped.
+	SaveDeadLetter(ctx context.Context, letter *entities.DeadLetter) error
+	// GetDeadLetters returns up to limit of the latest letters, newest first.
+	GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error)
+	// GetDeadLetter returns entities.ErrNotFound for an unknown id.
+	GetDeadLetter(ctx context.Context, id int64) (*entities.DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id int64) error
+}
@@ -0,0 +1,404 @@
+package cases
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+const (
+	DefaultWebhookMaxAttempts    = 5
+	DefaultWebhookInitialBackoff = time.Second
+	DefaultWebhookMaxBackoff     = time.Minute
+	DefaultWebhookQueueSize      = 256
+	DefaultWebhookWorkers        = 4
+
+	DefaultDeadLettersLimit = 100
+	MaxDeadLettersLimit     = 1000
+)
+
+type WebhooksConfig struct {
+	// MaxAttempts bounds the deliveries of an event to a webhook. Retries wait
+	// InitialBackoff, doubling up to MaxBackoff.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// QueueSize bounds the events waiting for a worker. Up to QueueSize more
+	// events published to a full queue are dead-lettered by Run, the ones
+	// beyond are dropped and reported to OnError.
+	QueueSize int
+	Workers   int
+	// OnError, if set, is called with every delivery that was dead-lettered and
+	// every error that could not be recorded.
+	OnError func(err error)
+}
+
+// Webhooks delivers the published events to the registered webhooks. Failed
+// deliveries are retried with exponential backoff and end up as dead letters,
+// which can be replayed.
+type Webhooks struct {
+	storage WebhookStorage
+	sender  WebhookSender
+	cfg     WebhooksConfig
+	queue   chan *entities.Event
+	// overflow holds the events published to a full queue, to be dead-lettered
+	// off the publisher's goroutine.
+	overflow chan *entities.Event
+	dropped  atomic.Uint64
+}
+
+var errQueueFull = errors.New("delivery queue is full")
+
+var _ EventPublisher = (*Webhooks)(nil)
+
+func NewWebhooks(storage WebhookStorage, sender WebhookSender, cfg WebhooksConfig) (*Webhooks, error) {
+	if storage == nil || storage == WebhookStorage(nil) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "webhook storage not set")
+	}
+
+	if sender == nil || sender == WebhookSender(nil) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "webhook sender not set")
+	}
+
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = DefaultWebhookInitialBackoff
+	}
+
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "max backoff is less than initial backoff")
+	}
+
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultWebhookQueueSize
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWebhookWorkers
+	}
+
+	if cfg.OnError == nil {
+		cfg.OnError = func(error) {}
+	}
+
+	return &Webhooks{
+		storage:  storage,
+		sender:   sender,
+		cfg:      cfg,
+		queue:    make(chan *entities.Event, cfg.QueueSize),
+		overflow: make(chan *entities.Event, cfg.QueueSize),
+	}, nil
+}
+
+// CreateWebhook validates and registers a webhook.
+func (w *Webhooks) CreateWebhook(ctx context.Context, webhook entities.Webhook) (*entities.Webhook, error) {
+	webhook.ID, webhook.CreatedAt = 0, time.Now()
+
+	if err := webhook.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid webhook")
+	}
+
+	created, err := w.storage.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook")
+	}
+
+	return created, nil
+}
+
+func (w *Webhooks) GetWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	webhooks, err := w.storage.GetWebhooks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhooks")
+	}
+
+	return webhooks, nil
+}
+
+func (w *Webhooks) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := w.storage.DeleteWebhook(ctx, id); err != nil {
+		return errors.Wrapf(err, "failed to delete webhook %d", id)
+	}
+
+	return nil
+}
+
+// GetDeadLetters returns the latest dead letters, newest first. A zero limit
+// means DefaultDeadLettersLimit.
+func (w *Webhooks) GetDeadLetters(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	if limit == 0 {
+		limit = DefaultDeadLettersLimit
+	}
+
+	if limit < 0 || limit > MaxDeadLettersLimit {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", MaxDeadLettersLimit)
+	}
+
+	letters, err := w.storage.GetDeadLetters(ctx, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get dead letters")
+	}
+
+	return letters, nil
+}
+
+// Replay makes a single attempt to deliver a dead letter again. The letter is
+// deleted once delivered and kept, with the attempt recorded, otherwise.
+func (w *Webhooks) Replay(ctx context.Context, id int64) error {
+	letter, err := w.storage.GetDeadLetter(ctx, id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get dead letter %d", id)
+	}
+
+	webhook, err := w.webhook(ctx, letter.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	if err = w.sender.Send(ctx, webhook, &letter.Delivery); err != nil {
+		letter.Attempts++
+		letter.LastError = err.Error()
+		letter.FailedAt = time.Now()
+
+		if saveErr := w.storage.SaveDeadLetter(ctx, letter); saveErr != nil {
+			w.cfg.OnError(errors.Wrapf(saveErr, "failed to save dead letter %d", id))
+		}
+
+		return errors.Wrapf(err, "failed to replay dead letter %d", id)
+	}
+
+	if err = w.storage.DeleteDeadLetter(ctx, id); err != nil {
+		return errors.Wrapf(err, "failed to delete replayed dead letter %d", id)
+	}
+
+	return nil
+}
+
+// Publish queues event for delivery by Run. It never blocks: an event that
+// fits neither the queue nor the overflow is dropped.
+func (w *Webhooks) Publish(_ context.Context, event *entities.Event) {
+	select {
+	case w.queue <- event:
+		return
+	default:
+	}
+
+	select {
+	case w.overflow <- event:
+	default:
+		dropped := w.dropped.Add(1)
+		w.cfg.OnError(errors.Wrapf(errQueueFull, "dropped event %s, %d dropped so far", event.ID, dropped))
+	}
+}
+
+// Dropped returns the number of events Publish dropped.
+func (w *Webhooks) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Run delivers the queued events and dead-letters the overflowing ones until
+// ctx is done, then dead-letters the events left so that they can be replayed.
+func (w *Webhooks) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-w.overflow:
+				w.deadLetterEvent(ctx, event, errQueueFull)
+			}
+		}
+	}()
+
+	for range w.cfg.Workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-w.queue:
+					// select picks at random when both cases are ready.
+					if ctx.Err() != nil {
+						w.deadLetterEvent(ctx, event, ctx.Err())
+						return
+					}
+
+					w.deliverEvent(ctx, event)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for {
+		select {
+		case event := <-w.queue:
+			w.deadLetterEvent(ctx, event, ctx.Err())
+		case event := <-w.overflow:
+			w.deadLetterEvent(ctx, event, errQueueFull)
+		default:
+			return
+		}
+	}
+}
+
+// deliverEvent delivers event to every subscribed webhook concurrently.
+func (w *Webhooks) deliverEvent(ctx context.Context, event *entities.Event) {
+	webhooks, delivery, err := w.prepare(ctx, event)
+	if err != nil {
+		w.cfg.OnError(err)
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	for _, webhook := range webhooks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			w.deliver(ctx, webhook, delivery)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// deliver retries a delivery with exponential backoff while it fails with
+// retryable errors, and dead-letters it once it gives up.
+func (w *Webhooks) deliver(ctx context.Context, webhook *entities.Webhook, delivery *entities.Delivery) {
+	backoff := w.cfg.InitialBackoff
+	attempts := 0
+
+	for {
+		attempts++
+
+		err := w.sender.Send(ctx, webhook, delivery)
+		if err == nil {
+			return
+		}
+
+		if !entities.IsRetryable(err) || attempts >= w.cfg.MaxAttempts || !sleep(ctx, backoff) {
+			w.deadLetter(ctx, webhook, delivery, attempts, err)
+			return
+		}
+
+		backoff = min(2*backoff, w.cfg.MaxBackoff)
+	}
+}
+
+func (w *Webhooks) deadLetterEvent(ctx context.Context, event *entities.Event, cause error) {
+	webhooks, delivery, err := w.prepare(context.WithoutCancel(ctx), event)
+	if err != nil {
+		w.cfg.OnError(err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		w.deadLetter(ctx, webhook, delivery, 0, cause)
+	}
+}
+
+func (w *Webhooks) deadLetter(
+	ctx context.Context,
+	webhook *entities.Webhook,
+	delivery *entities.Delivery,
+	attempts int,
+	cause error,
+) {
+	letter := &entities.DeadLetter{
+		WebhookID: webhook.ID,
+		Delivery:  *delivery,
+		Attempts:  attempts,
+		LastError: cause.Error(),
+		FailedAt:  time.Now(),
+	}
+
+	// The letter is saved also when ctx is done, e.g. on shutdown.
+	if err := w.storage.SaveDeadLetter(context.WithoutCancel(ctx), letter); err != nil {
+		w.cfg.OnError(errors.Wrapf(err, "failed to save dead letter of event %s for webhook %d", delivery.EventID, webhook.ID))
+		return
+	}
+
+	w.cfg.OnError(errors.Wrapf(cause, "dead-lettered event %s for webhook %d after %d attempts",
+		delivery.EventID, webhook.ID, attempts))
+}
+
+// prepare returns the webhooks subscribed to event and the encoded event.
+func (w *Webhooks) prepare(ctx context.Context, event *entities.Event) ([]*entities.Webhook, *entities.Delivery, error) {
+	webhooks, err := w.storage.GetWebhooks(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get webhooks for event %s", event.ID)
+	}
+
+	subscribed := make([]*entities.Webhook, 0, len(webhooks))
+
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event.Type) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+
+	if len(subscribed) == 0 {
+		return nil, nil, nil
+	}
+
+	delivery, err := w.sender.Encode(event)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to encode event %s", event.ID)
+	}
+
+	return subscribed, delivery, nil
+}
+
+func (w *Webhooks) webhook(ctx context.Context, id int64) (*entities.Webhook, error) {
+	webhooks, err := w.storage.GetWebhooks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhooks")
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+
+	return nil, errors.Wrapf(entities.ErrNotFound, "webhook %d", id)
+}
+
+// sleep waits for d and reports whether ctx is still alive.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,367 @@
+package cases_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"crypto-project/internal/cases"
+	"crypto-project/internal/cases/mocks"
+	"crypto-project/internal/entities"
+)
+
+var (
+	errUnavailable = entities.NewDeliveryError(true, errors.New("status 503"))
+	errGone        = entities.NewDeliveryError(false, errors.New("status 410"))
+)
+
+// runWebhooks runs webhooks until the test ends.
+func runWebhooks(t *testing.T, webhooks *cases.Webhooks) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		webhooks.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+}
+
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+}
+
+func TestWebhooksDeliver(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name             string
+		errs             []error
+		expectedAttempts int
+		expectedLetter   bool
+	}{
+		{name: "delivered", errs: []error{nil}, expectedAttempts: 1},
+		{name: "delivered after retries", errs: []error{errUnavailable, errUnavailable, nil}, expectedAttempts: 3},
+		{name: "retries exhausted", errs: []error{errUnavailable, errUnavailable, errUnavailable}, expectedAttempts: 3, expectedLetter: true},
+		{name: "permanent failure", errs: []error{errGone}, expectedAttempts: 1, expectedLetter: true},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockStorage := mocks.NewMockWebhookStorage(ctrl)
+			mockSender := mocks.NewMockWebhookSender(ctrl)
+
+			webhooks, err := cases.NewWebhooks(mockStorage, mockSender, cases.WebhooksConfig{
+				MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond,
+			})
+			require.NoError(t, err)
+
+			event := entities.NewRatesEvent([]*entities.Coin{{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(1)}}, time.Now())
+			hook := &entities.Webhook{ID: 1, URL: "https://example.com/hook", Secret: "s3cret"}
+			delivery := &entities.Delivery{EventID: event.ID, EventType: event.Type, Payload: []byte("{}")}
+			done := make(chan struct{})
+
+			mockStorage.EXPECT().GetWebhooks(gomock.Any()).Return([]*entities.Webhook{hook}, nil)
+			mockSender.EXPECT().Encode(event).Return(delivery, nil)
+
+			attempts := 0
+
+			mockSender.EXPECT().
+				Send(gomock.Any(), hook, delivery).
+				DoAndReturn(func(context.Context, *entities.Webhook, *entities.Delivery) error {
+					err := tc.errs[attempts]
+					attempts++
+
+					if err == nil {
+						close(done)
+					}
+
+					return err
+				}).
+				Times(tc.expectedAttempts)
+
+			if tc.expectedLetter {
+				mockStorage.EXPECT().
+					SaveDeadLetter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, letter *entities.DeadLetter) error {
+						require.Equal(t, hook.ID, letter.WebhookID)
+						require.Equal(t, *delivery, letter.Delivery)
+						require.Equal(t, tc.expectedAttempts, letter.Attempts)
+						require.Equal(t, tc.errs[len(tc.errs)-1].Error(), letter.LastError)
+						close(done)
+
+						return nil
+					})
+			}
+
+			runWebhooks(t, webhooks)
+			webhooks.Publish(context.Background(), event)
+			waitFor(t, done)
+		})
+	}
+}
+
+func TestWebhooksSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockWebhookStorage(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+
+	webhooks, err := cases.NewWebhooks(mockStorage, mockSender, cases.WebhooksConfig{})
+	require.NoError(t, err)
+
+	all := &entities.Webhook{ID: 1}
+	alerts := &entities.Webhook{ID: 2, Events: []entities.EventType{entities.EventAlertFired}}
+	event := entities.NewRatesEvent(nil, time.Now())
+	delivery := &entities.Delivery{EventID: event.ID, EventType: event.Type}
+	done := make(chan struct{})
+
+	mockStorage.EXPECT().GetWebhooks(gomock.Any()).Return([]*entities.Webhook{all, alerts}, nil)
+	mockSender.EXPECT().Encode(event).Return(delivery, nil)
+	mockSender.EXPECT().
+		Send(gomock.Any(), all, delivery).
+		DoAndReturn(func(context.Context, *entities.Webhook, *entities.Delivery) error {
+			close(done)
+			return nil
+		})
+
+	runWebhooks(t, webhooks)
+	webhooks.Publish(context.Background(), event)
+	waitFor(t, done)
+}
+
+func TestWebhooksShutdown(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockWebhookStorage(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+
+	var (
+		// Run dead-letters the queued and the overflowing events concurrently.
+		mu      sync.Mutex
+		failed  []error
+		letters = make(map[string]*entities.DeadLetter)
+	)
+
+	webhooks, err := cases.NewWebhooks(mockStorage, mockSender, cases.WebhooksConfig{
+		QueueSize: 1,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			failed = append(failed, err)
+		},
+	})
+	require.NoError(t, err)
+
+	hook := &entities.Webhook{ID: 1}
+	queued := entities.NewRatesEvent(nil, time.Now())
+	overflow := entities.NewRatesEvent(nil, time.Now())
+	dropped := entities.NewRatesEvent(nil, time.Now())
+
+	mockStorage.EXPECT().GetWebhooks(gomock.Any()).Return([]*entities.Webhook{hook}, nil).Times(2)
+	mockSender.EXPECT().
+		Encode(gomock.Any()).
+		DoAndReturn(func(event *entities.Event) (*entities.Delivery, error) {
+			return &entities.Delivery{EventID: event.ID, EventType: event.Type}, nil
+		}).
+		Times(2)
+	mockStorage.EXPECT().
+		SaveDeadLetter(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, letter *entities.DeadLetter) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			letters[letter.Delivery.EventID] = letter
+			return nil
+		}).
+		Times(2)
+
+	// Publishing to a full queue does not touch the storage.
+	webhooks.Publish(context.Background(), queued)
+	webhooks.Publish(context.Background(), overflow)
+	webhooks.Publish(context.Background(), dropped)
+
+	require.Empty(t, letters)
+	require.Equal(t, uint64(1), webhooks.Dropped())
+	require.Len(t, failed, 1)
+	require.ErrorContains(t, failed[0], dropped.ID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	webhooks.Run(ctx)
+
+	require.Len(t, letters, 2)
+	require.Zero(t, letters[queued.ID].Attempts)
+	require.Equal(t, context.Canceled.Error(), letters[queued.ID].LastError)
+	require.Contains(t, letters[overflow.ID].LastError, "queue is full")
+	require.Len(t, failed, 3)
+}
+
+func TestWebhooksReplay(t *testing.T) {
+	t.Parallel()
+
+	hook := &entities.Webhook{ID: 1}
+	letter := func() *entities.DeadLetter {
+		return &entities.DeadLetter{ID: 7, WebhookID: hook.ID, Delivery: entities.Delivery{EventID: "1"}, Attempts: 5, LastError: "status 503"}
+	}
+
+	t.Run("delivered", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockStorage := mocks.NewMockWebhookStorage(ctrl)
+		mockSender := mocks.NewMockWebhookSender(ctrl)
+
+		webhooks, err := cases.NewWebhooks(mockStorage, mockSender, cases.WebhooksConfig{})
+		require.NoError(t, err)
+
+		gomock.InOrder(
+			mockStorage.EXPECT().GetDeadLetter(gomock.Any(), int64(7)).Return(letter(), nil),
+			mockStorage.EXPECT().GetWebhooks(gomock.Any()).Return([]*entities.Webhook{hook}, nil),
+			mockSender.EXPECT().Send(gomock.Any(), hook, &letter().Delivery).Return(nil),
+			mockStorage.EXPECT().DeleteDeadLetter(gomock.Any(), int64(7)).Return(nil),
+		)
+
+		require.NoError(t, webhooks.Replay(context.Background(), 7))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockStorage := mocks.NewMockWebhookStorage(ctrl)
+		mockSender := mocks.NewMockWebhookSender(ctrl)
+
+		webhooks, err := cases.NewWebhooks(mockStorage, mockSender, cases.WebhooksConfig{})
+		require.NoError(t, err)
+
+		gomock.InOrder(
+			mockStorage.EXPECT().GetDeadLetter(gomock.Any(), int64(7)).Return(letter(), nil),
+			mockStorage.EXPECT().GetWebhooks(gomock.Any()).Return([]*entities.Webhook{hook}, nil),
+			mockSender.EXPECT().Send(gomock.Any(), hook, gomock.Any()).Return(errGone),
+			mockStorage.EXPECT().
+				SaveDeadLetter(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, saved *entities.DeadLetter) error {
+					require.Equal(t, int64(7), saved.ID)
+					require.Equal(t, 6, saved.Attempts)
+					require.Equal(t, errGone.Error(), saved.LastError)
+					return nil
+				}),
+		)
+
+		err = webhooks.Replay(context.Background(), 7)
+		require.ErrorIs(t, err, entities.ErrDelivery)
+		require.False(t, entities.IsRetryable(err))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockStorage := mocks.NewMockWebhookStorage(ctrl)
+
+		webhooks, err := cases.NewWebhooks(mockStorage, mocks.NewMockWebhookSender(ctrl), cases.WebhooksConfig{})
+		require.NoError(t, err)
+
+		mockStorage.EXPECT().
+			GetDeadLetter(gomock.Any(), int64(7)).
+			Return(nil, errors.Wrap(entities.ErrNotFound, "dead letter 7"))
+
+		require.ErrorIs(t, webhooks.Replay(context.Background(), 7), entities.ErrNotFound)
+	})
+}
+
+func TestNewWebhooks(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	_, err := cases.NewWebhooks(nil, mocks.NewMockWebhookSender(ctrl), cases.WebhooksConfig{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = cases.NewWebhooks(mocks.NewMockWebhookStorage(ctrl), nil, cases.WebhooksConfig{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = cases.NewWebhooks(mocks.NewMockWebhookStorage(ctrl), mocks.NewMockWebhookSender(ctrl), cases.WebhooksConfig{
+		InitialBackoff: time.Minute, MaxBackoff: time.Second,
+	})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	webhooks, err := cases.NewWebhooks(mocks.NewMockWebhookStorage(ctrl), mocks.NewMockWebhookSender(ctrl), cases.WebhooksConfig{})
+	require.NoError(t, err)
+
+	_, err = webhooks.CreateWebhook(context.Background(), entities.Webhook{URL: "ftp://example.com", Secret: "s3cret"})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = webhooks.GetDeadLetters(context.Background(), cases.MaxDeadLettersLimit+1)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestActualizeRatesPublishesEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockCryptoProvider := mocks.NewMockCryptoProvider(ctrl)
+	mockAlertStorage := mocks.NewMockAlertStorage(ctrl)
+	mockEvents := mocks.NewMockEventPublisher(ctrl)
+
+	service := &cases.Service{
+		Storage:  mockStorage,
+		Provider: mockCryptoProvider,
+		Alerts:   &cases.Alerts{Storage: mockStorage, AlertStorage: mockAlertStorage},
+		Events:   mockEvents,
+	}
+
+	now := time.Now()
+	coins := []*entities.Coin{{Title: "BTC", Quote: "USD", Cost: decimal.NewFromInt(150), ActualAt: now}}
+	rule := &entities.AlertRule{ID: 1, Title: "BTC", Quote: "USD", Condition: entities.AlertAbove, Threshold: decimal.NewFromInt(100)}
+
+	var published []*entities.Event
+
+	mockStorage.EXPECT().GetQuotesList(gomock.Any()).Return([]string{"USD"}, nil)
+	mockStorage.EXPECT().GetCoinsList(gomock.Any(), "USD").Return([]string{"BTC"}, nil)
+	mockCryptoProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}, "USD").Return(coins, nil)
+	mockStorage.EXPECT().Store(gomock.Any(), coins).Return(nil)
+	mockAlertStorage.EXPECT().GetAlertRules(gomock.Any()).Return([]*entities.AlertRule{rule}, nil)
+	mockStorage.EXPECT().GetActualCoin(gomock.Any(), []string{"BTC"}, "USD").Return(coins, nil)
+	mockAlertStorage.EXPECT().SaveAlertEvaluation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockEvents.EXPECT().
+		Publish(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *entities.Event) {
+			published = append(published, event)
+		}).
+		Times(2)
+
+	require.NoError(t, service.ActualizeRates(context.Background()))
+
+	require.Len(t, published, 2)
+	require.Equal(t, entities.EventRatesActualized, published[0].Type)
+	require.Equal(t, coins, published[0].Rates)
+	require.Equal(t, entities.EventAlertFired, published[1].Type)
+	require.Equal(t, rule.ID, published[1].Alert.RuleID)
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// AlertCondition is what an AlertRule watches the rate of its title for.
+type AlertCondition string
+
+const (
+	// AlertAbove matches while the rate is at or above Threshold.
+	AlertAbove AlertCondition = "above"
+	// AlertBelow matches while the rate is at or below Threshold.
+	AlertBelow AlertCondition = "below"
+	// AlertChange matches while the rate moved by at least Threshold percent,
+	// up or down, within Window.
+	AlertChange AlertCondition = "change"
+)
+
+// AlertState is the state an AlertEvent reports a rule entering.
+type AlertState string
+
+const (
+	AlertFiring   AlertState = "firing"
+	AlertResolved AlertState = "resolved"
+)
+
+// AlertRule watches the rate of Title in Quote. A rule fires when its
+// condition starts matching and resolves when it stops, but does not fire again
+// within Cooldown of its last firing.
+type AlertRule struct {
+	ID        int64
+	Title     string
+	Quote     string
+	Condition AlertCondition
+	// Threshold is a rate for AlertAbove and AlertBelow and a percentage for
+	// AlertChange, e.g. 5 for 5%.
+	Threshold decimal.Decimal
+	// Window is the lookback of AlertChange, unused by other conditions.
+	Window   time.Duration
+	Cooldown time.Duration
+
+	// Firing and FiredAt are maintained by evaluation.
+	Firing  bool
+	FiredAt time.Time
+}
+
+func (r *AlertRule) Validate() error {
+	if r.Title == "" {
+		return errors.Wrap(ErrInvalidParam, "title cannot be empty")
+	}
+
+	if r.Quote == "" {
+		return errors.Wrap(ErrInvalidParam, "quote cannot be empty")
+	}
+
+	switch r.Condition {
+	case AlertAbove, AlertBelow:
+	case AlertChange:
+		if r.Window <= 0 {
+			return errors.Wrap(ErrInvalidParam, "window must be positive")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidParam, "unknown condition %q", r.Condition)
+	}
+
+	if !r.Threshold.IsPositive() {
+		return errors.Wrap(ErrInvalidParam, "threshold must be positive")
+	}
+
+	if r.Cooldown < 0 {
+		return errors.Wrap(ErrInvalidParam, "cooldown cannot be negative")
+	}
+
+	return nil
+}
+
+// AlertEvent records a rule firing or resolving.
+type AlertEvent struct {
+	ID     int64
+	RuleID int64
+	Title  string
+	Quote  string
+	State  AlertState
+	// Cost is the rate the rule was evaluated against.
+	Cost decimal.Decimal
+	// Value is what was compared to the threshold: Cost, or the percentage
+	// change for AlertChange.
+	Value decimal.Decimal
+	At    time.Time
+}
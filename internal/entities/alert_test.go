@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := AlertRule{Title: "BTC", Quote: "USD", Condition: AlertAbove, Threshold: decimal.NewFromInt(100000)}
+
+	testTable := []struct {
+		name    string
+		modify  func(rule *AlertRule)
+		wantErr bool
+	}{
+		{name: "above", modify: func(rule *AlertRule) {}},
+		{name: "below", modify: func(rule *AlertRule) { rule.Condition = AlertBelow }},
+		{name: "change", modify: func(rule *AlertRule) { rule.Condition, rule.Window = AlertChange, time.Hour }},
+		{name: "cooldown", modify: func(rule *AlertRule) { rule.Cooldown = time.Hour }},
+		{name: "empty title", modify: func(rule *AlertRule) { rule.Title = "" }, wantErr: true},
+		{name: "empty quote", modify: func(rule *AlertRule) { rule.Quote = "" }, wantErr: true},
+		{name: "unknown condition", modify: func(rule *AlertRule) { rule.Condition = "crosses" }, wantErr: true},
+		{name: "change without window", modify: func(rule *AlertRule) { rule.Condition = AlertChange }, wantErr: true},
+		{name: "zero threshold", modify: func(rule *AlertRule) { rule.Threshold = decimal.Zero }, wantErr: true},
+		{name: "negative cooldown", modify: func(rule *AlertRule) { rule.Cooldown = -time.Second }, wantErr: true},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := valid
+			tc.modify(&rule)
+
+			err := rule.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidParam)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
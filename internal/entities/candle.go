@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Interval is the width of a candle.
+type Interval time.Duration
+
+const (
+	Interval1m = Interval(time.Minute)
+	Interval5m = Interval(5 * time.Minute)
+	Interval1h = Interval(time.Hour)
+	Interval1d = Interval(24 * time.Hour)
+)
+
+var intervalNames = map[Interval]string{
+	Interval1m: "1m",
+	Interval5m: "5m",
+	Interval1h: "1h",
+	Interval1d: "1d",
+}
+
+// ParseInterval parses one of the supported interval names: 1m, 5m, 1h or 1d.
+func ParseInterval(name string) (Interval, error) {
+	for interval, intervalName := range intervalNames {
+		if intervalName == name {
+			return interval, nil
+		}
+	}
+
+	return 0, errors.Wrapf(ErrInvalidParam, "unsupported interval %q", name)
+}
+
+func (i Interval) Validate() error {
+	if _, ok := intervalNames[i]; !ok {
+		return errors.Wrapf(ErrInvalidParam, "unsupported interval %s", time.Duration(i))
+	}
+
+	return nil
+}
+
+func (i Interval) String() string {
+	if name, ok := intervalNames[i]; ok {
+		return name
+	}
+
+	return time.Duration(i).String()
+}
+
+// Start returns the start of the candle t falls into. Candles are aligned to
+// the Unix epoch, so daily candles start at midnight UTC.
+func (i Interval) Start(t time.Time) time.Time {
+	d := int64(i)
+	nanos := t.UnixNano() % d
+
+	if nanos < 0 {
+		nanos += d
+	}
+
+	return t.Add(-time.Duration(nanos)).UTC()
+}
+
+// Candle summarizes the rates of a title stored within [Start, Start+Interval).
+// Start is in UTC.
+type Candle struct {
+	Title    string
+	Quote    string
+	Interval Interval
+	Start    time.Time
+	Open     decimal.Decimal
+	High     decimal.Decimal
+	Low      decimal.Decimal
+	Close    decimal.Decimal
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"1m", "5m", "1h", "1d"} {
+		interval, err := ParseInterval(name)
+		require.NoError(t, err, name)
+		require.NoError(t, interval.Validate(), name)
+		require.Equal(t, name, interval.String())
+	}
+
+	for _, name := range []string{"", "2h", "1w", "60s"} {
+		_, err := ParseInterval(name)
+		require.ErrorIs(t, err, ErrInvalidParam, name)
+	}
+
+	require.ErrorIs(t, Interval(time.Second).Validate(), ErrInvalidParam)
+}
+
+func TestIntervalStart(t *testing.T) {
+	t.Parallel()
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	t1 := time.Date(2024, time.March, 10, 1, 47, 31, 500, moscow)
+
+	testTable := []struct {
+		interval Interval
+		expected time.Time
+	}{
+		{interval: Interval1m, expected: time.Date(2024, time.March, 9, 22, 47, 0, 0, time.UTC)},
+		{interval: Interval5m, expected: time.Date(2024, time.March, 9, 22, 45, 0, 0, time.UTC)},
+		{interval: Interval1h, expected: time.Date(2024, time.March, 9, 22, 0, 0, 0, time.UTC)},
+		{interval: Interval1d, expected: time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.interval.String(), func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.interval.Start(t1))
+		})
+	}
+
+	beforeEpoch := time.Date(1969, time.December, 31, 23, 59, 30, 0, time.UTC)
+	require.Equal(t, time.Date(1969, time.December, 31, 23, 59, 0, 0, time.UTC), Interval1m.Start(beforeEpoch))
+}
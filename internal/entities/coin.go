@@ -2,26 +2,68 @@ package entities
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
+// DefaultQuote is the quote currency of callers that do not ask for one.
+const DefaultQuote = "USD"
+
+// PriceScale is the number of fractional digits a price is rounded to when it
+// has no exact decimal result, e.g. an average.
+const PriceScale = 18
+
 type Coin struct {
-	Title    string
-	Cost     float64
+	Title string
+	// Quote is the currency Cost is denominated in, e.g. USD, EUR or BTC.
+	Quote string
+	// Cost is exact: it keeps every digit the provider quoted and serializes to
+	// JSON as a string.
+	Cost     decimal.Decimal
 	ActualAt time.Time
+	// Seq is set by the storage that stored the coin and increases with every
+	// coin it stores. It is zero for coins that were not stored.
+	Seq int64
 }
 
-func NewCoin(title string, cost float64, actualAt time.Time) (*Coin, error) {
+func NewCoin(title, quote string, cost decimal.Decimal, actualAt time.Time) (*Coin, error) {
 	if title == "" {
 		return nil, fmt.Errorf("title cannot be empty")
-	} else if cost <= 0 {
+	} else if quote == "" {
+		return nil, fmt.Errorf("quote cannot be empty")
+	} else if !cost.IsPositive() {
 		return nil, fmt.Errorf("cost must be positiv")
 	} else if actualAt.IsZero() {
 		return nil, fmt.Errorf("actualAt cannot be zero")
 	}
 	return &Coin{
 		Title:    title,
+		Quote:    quote,
 		Cost:     cost,
 		ActualAt: actualAt,
 	}, nil
 }
+
+// NormalizeQuote uppercases a quote currency code such as "eur" and defaults
+// an empty one to DefaultQuote.
+func NormalizeQuote(quote string) (string, error) {
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if quote == "" {
+		return DefaultQuote, nil
+	}
+
+	if len(quote) < 2 || len(quote) > 10 {
+		return "", errors.Wrapf(ErrInvalidParam, "invalid quote %q", quote)
+	}
+
+	for _, r := range quote {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return "", errors.Wrapf(ErrInvalidParam, "invalid quote %q", quote)
+		}
+	}
+
+	return quote, nil
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -10,42 +11,71 @@ func TestNewCoin(t *testing.T) {
 	testTable := []struct {
 		Name     string
 		Title    string
-		Cost     float64
+		Quote    string
+		Cost     decimal.Decimal
 		ActualAt time.Time
 		WantErr  bool
 	}{
 		{
 			Name:     "Valid data",
 			Title:    "Bitcoin",
-			Cost:     125.2,
+			Quote:    "USD",
+			Cost:     decimal.RequireFromString("125.2"),
 			ActualAt: time.Now(),
 			WantErr:  false,
 		},
 		{
 			Name:     "Empty name",
 			Title:    "",
-			Cost:     125.2,
+			Quote:    "USD",
+			Cost:     decimal.RequireFromString("125.2"),
 			ActualAt: time.Now(),
 			WantErr:  true,
 		},
 		{
 			Name:     "Invalid cost",
 			Title:    "Bitcoin",
-			Cost:     0,
+			Quote:    "USD",
+			Cost:     decimal.Zero,
+			ActualAt: time.Now(),
+			WantErr:  true,
+		},
+		{
+			Name:     "Negative cost",
+			Title:    "Bitcoin",
+			Quote:    "USD",
+			Cost:     decimal.RequireFromString("-0.000000001"),
+			ActualAt: time.Now(),
+			WantErr:  true,
+		},
+		{
+			Name:     "Sub-satoshi cost",
+			Title:    "Bitcoin",
+			Quote:    "USD",
+			Cost:     decimal.RequireFromString("0.000000000000000001"),
+			ActualAt: time.Now(),
+			WantErr:  false,
+		},
+		{
+			Name:     "Empty quote",
+			Title:    "Bitcoin",
+			Quote:    "",
+			Cost:     decimal.RequireFromString("125.2"),
 			ActualAt: time.Now(),
 			WantErr:  true,
 		},
 		{
 			Name:     "Zero ActualAt",
 			Title:    "Bitcoin",
-			Cost:     125.2,
+			Quote:    "USD",
+			Cost:     decimal.RequireFromString("125.2"),
 			ActualAt: time.Time{},
 			WantErr:  true,
 		},
 	}
 	for _, testCase := range testTable {
 		t.Run(testCase.Name, func(*testing.T) {
-			_, err := NewCoin(testCase.Title, testCase.Cost, testCase.ActualAt)
+			_, err := NewCoin(testCase.Title, testCase.Quote, testCase.Cost, testCase.ActualAt)
 			if testCase.WantErr {
 				assert.Error(t, err, "Expected an error for test case:"+testCase.Name)
 			} else {
@@ -54,3 +84,30 @@ func TestNewCoin(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeQuote(t *testing.T) {
+	testTable := []struct {
+		quote    string
+		expected string
+		wantErr  bool
+	}{
+		{quote: "", expected: DefaultQuote},
+		{quote: " eur ", expected: "EUR"},
+		{quote: "USDT", expected: "USDT"},
+		{quote: "btc", expected: "BTC"},
+		{quote: "E", wantErr: true},
+		{quote: "US-D", wantErr: true},
+		{quote: "VERYLONGQUOTE", wantErr: true},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.quote, func(t *testing.T) {
+			quote, err := NormalizeQuote(testCase.quote)
+			if testCase.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidParam)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, quote)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Conversion is an amount of From expressed in To.
+type Conversion struct {
+	From   string
+	To     string
+	Amount decimal.Decimal
+	Result decimal.Decimal
+	// Rate is the price of one From in To.
+	Rate decimal.Decimal
+	// Legs are the stored rates Rate was derived from, in the order they were
+	// applied: one for a direct or inverse pair, two when triangulated through
+	// a common quote.
+	Legs []*Coin
+	// ActualAt is the time of the oldest leg.
+	ActualAt time.Time
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// RateCursor is a position in the rates history: the Seq of a stored rate.
+// Sequences follow the order rates were stored in, whatever their actual
+// time, so that a rate stored late is never skipped.
+type RateCursor struct {
+	Seq int64
+}
+
+func CursorOf(coin *Coin) RateCursor {
+	return RateCursor{Seq: coin.Seq}
+}
+
+// ParseRateCursor reads a cursor written by RateCursor.String.
+func ParseRateCursor(value string) (RateCursor, error) {
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return RateCursor{}, errors.Wrapf(ErrInvalidParam, "invalid cursor %q", value)
+	}
+
+	return RateCursor{Seq: seq}, nil
+}
+
+// String writes the cursor as its sequence, such as 42.
+func (c RateCursor) String() string {
+	return strconv.FormatInt(c.Seq, 10)
+}
+
+func (c RateCursor) Compare(other RateCursor) int {
+	return cmp.Compare(c.Seq, other.Seq)
+}
+
+// FilterRates returns the rates of event in quote of titles, ordered by cursor.
+func FilterRates(event *Event, titles []string, quote string) []*Coin {
+	coins := make([]*Coin, 0, len(event.Rates))
+
+	for _, coin := range event.Rates {
+		if coin.Quote == quote && slices.Contains(titles, coin.Title) {
+			coins = append(coins, coin)
+		}
+	}
+
+	slices.SortStableFunc(coins, func(a, b *Coin) int {
+		return CursorOf(a).Compare(CursorOf(b))
+	})
+
+	return coins
+}
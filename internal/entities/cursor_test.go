@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateCursor(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	cursor := CursorOf(&Coin{Title: "BTC", ActualAt: at, Seq: 42})
+
+	require.Equal(t, "42", cursor.String())
+
+	parsed, err := ParseRateCursor(cursor.String())
+	require.NoError(t, err)
+	require.Equal(t, cursor, parsed)
+
+	// Only the sequence orders cursors, not the actual time.
+	require.Negative(t, cursor.Compare(CursorOf(&Coin{Title: "ETH", ActualAt: at.Add(-time.Hour), Seq: 43})))
+	require.Positive(t, cursor.Compare(CursorOf(&Coin{Title: "BTC", ActualAt: at, Seq: 41})))
+
+	for _, value := range []string{"", "BTC", "-1", "1704110400123456:BTC"} {
+		_, err = ParseRateCursor(value)
+		require.ErrorIs(t, err, ErrInvalidParam, value)
+	}
+}
+
+func TestFilterRates(t *testing.T) {
+	t.Parallel()
+
+	btc := &Coin{Title: "BTC", Quote: "USD", Seq: 3}
+	eth := &Coin{Title: "ETH", Quote: "USD", Seq: 2}
+	event := NewRatesEvent([]*Coin{
+		btc,
+		{Title: "SOL", Quote: "USD", Seq: 4},
+		{Title: "BTC", Quote: "EUR", Seq: 5},
+		eth,
+	}, time.Now())
+
+	require.Equal(t, []*Coin{eth, btc}, FilterRates(event, []string{"BTC", "ETH"}, "USD"))
+	require.Empty(t, FilterRates(event, []string{"DOGE"}, "USD"))
+}
@@ -1 +1,226 @@
 package entities
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ErrInvalidParam = errors.New("invalid param")
+	ErrNotFound     = errors.New("not found")
+	ErrStorage      = errors.New("storage error")
+	ErrProvider     = errors.New("provider error")
+	ErrInternal     = errors.New("internal error")
+	ErrStaleRate    = errors.New("stale rate")
+	ErrDelivery     = errors.New("delivery error")
+)
+
+// Code is a stable machine-readable error kind, safe to expose to clients.
+type Code string
+
+const (
+	CodeInvalidParam Code = "invalid_param"
+	CodeNotFound     Code = "not_found"
+	CodeStorage      Code = "storage_failure"
+	CodeProvider     Code = "provider_failure"
+	CodeInternal     Code = "internal"
+	CodeStaleRate    Code = "stale_rate"
+	CodeDelivery     Code = "delivery_failure"
+)
+
+var sentinels = map[Code]error{
+	CodeInvalidParam: ErrInvalidParam,
+	CodeNotFound:     ErrNotFound,
+	CodeStorage:      ErrStorage,
+	CodeProvider:     ErrProvider,
+	CodeInternal:     ErrInternal,
+	CodeStaleRate:    ErrStaleRate,
+	CodeDelivery:     ErrDelivery,
+}
+
+// Error is a domain error. errors.Is matches it against the sentinel of its
+// Code as well as against its cause.
+type Error struct {
+	Code Code
+	// Titles the operation failed for, if it is specific to some coins.
+	Titles []string
+	// Source names the failed provider or storage, e.g. "coingecko" or "postgres".
+	Source string
+	// Retryable reports whether repeating the operation may succeed.
+	Retryable bool
+	Err       error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(e.sentinel().Error())
+
+	if e.Source != "" {
+		sb.WriteString(" (" + e.Source + ")")
+	}
+
+	if len(e.Titles) > 0 {
+		sb.WriteString(" [" + strings.Join(e.Titles, ", ") + "]")
+	}
+
+	if e.Err != nil {
+		sb.WriteString(": " + e.Err.Error())
+	}
+
+	return sb.String()
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.sentinel()}
+	}
+
+	return []error{e.sentinel(), e.Err}
+}
+
+func (e *Error) sentinel() error {
+	if sentinel, ok := sentinels[e.Code]; ok {
+		return sentinel
+	}
+
+	return ErrInternal
+}
+
+func NewInvalidParamError(cause error) *Error {
+	return &Error{Code: CodeInvalidParam, Err: cause}
+}
+
+func NewNotFoundError(titles []string) *Error {
+	return &Error{Code: CodeNotFound, Titles: titles}
+}
+
+// NewStaleRateError reports that the latest stored rates of titles are too old
+// to be used.
+func NewStaleRateError(titles []string, cause error) *Error {
+	return &Error{Code: CodeStaleRate, Titles: titles, Err: cause}
+}
+
+// NewDeliveryError reports a failed webhook delivery. Retryable failures, such
+// as timeouts and 5xx answers, may succeed when delivered again.
+func NewDeliveryError(retryable bool, cause error) *Error {
+	return &Error{Code: CodeDelivery, Retryable: retryable, Err: cause}
+}
+
+func NewStorageError(source string, retryable bool, cause error) *Error {
+	return &Error{Code: CodeStorage, Source: source, Retryable: retryable, Err: cause}
+}
+
+func NewProviderError(source string, titles []string, retryable bool, cause error) *Error {
+	return &Error{Code: CodeProvider, Titles: titles, Source: source, Retryable: retryable, Err: cause}
+}
+
+// CodeOf returns the Code of the first domain error in err's chain, falling
+// back to the sentinels. It returns "" for nil and CodeInternal for unknown errors.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+
+	for _, code := range []Code{CodeInvalidParam, CodeNotFound, CodeStorage, CodeProvider, CodeStaleRate, CodeDelivery} {
+		if errors.Is(err, sentinels[code]) {
+			return code
+		}
+	}
+
+	return CodeInternal
+}
+
+// IsRetryable reports whether err is a domain error marked retryable or a deadline.
+func IsRetryable(err error) bool {
+	var domainErr *Error
+	if errors.As(err, &domainErr) && domainErr.Retryable {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// TitlesOf returns the titles of the first domain error in err's chain.
+func TitlesOf(err error) []string {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Titles
+	}
+
+	return nil
+}
+
+// HTTPStatus maps err to the status code a transport should answer with.
+func HTTPStatus(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		// Not in net/http: the de facto "client closed request" status.
+		return 499
+	}
+
+	switch CodeOf(err) {
+	case CodeInvalidParam:
+		return http.StatusBadRequest
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeStaleRate:
+		return http.StatusUnprocessableEntity
+	case CodeProvider, CodeDelivery:
+		if IsRetryable(err) {
+			return http.StatusServiceUnavailable
+		}
+
+		return http.StatusBadGateway
+	case CodeStorage:
+		if IsRetryable(err) {
+			return http.StatusServiceUnavailable
+		}
+
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode maps err to the gRPC status code a transport should answer with.
+func GRPCCode(err error) codes.Code {
+	switch {
+	case err == nil:
+		return codes.OK
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+
+	switch CodeOf(err) {
+	case CodeInvalidParam:
+		return codes.InvalidArgument
+	case CodeNotFound:
+		return codes.NotFound
+	case CodeStaleRate:
+		return codes.FailedPrecondition
+	case CodeProvider, CodeStorage, CodeDelivery:
+		if IsRetryable(err) {
+			return codes.Unavailable
+		}
+
+		return codes.Internal
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := errors.Wrap(NewProviderError("coingecko", []string{"BTC", "ETH"}, true, cause), "failed to get actual rates")
+
+	assert.ErrorIs(t, err, ErrProvider)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrStorage)
+	assert.Equal(t, CodeProvider, CodeOf(err))
+	assert.Equal(t, []string{"BTC", "ETH"}, TitlesOf(err))
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, "failed to get actual rates: provider error (coingecko) [BTC, ETH]: connection refused", err.Error())
+}
+
+func TestErrorMapping(t *testing.T) {
+	testTable := []struct {
+		Name       string
+		Err        error
+		Code       Code
+		HTTPStatus int
+		GRPCCode   codes.Code
+	}{
+		{
+			Name:       "nil",
+			Err:        nil,
+			Code:       "",
+			HTTPStatus: http.StatusOK,
+			GRPCCode:   codes.OK,
+		},
+		{
+			Name:       "wrapped invalid param sentinel",
+			Err:        errors.Wrap(ErrInvalidParam, "titles cannot be empty"),
+			Code:       CodeInvalidParam,
+			HTTPStatus: http.StatusBadRequest,
+			GRPCCode:   codes.InvalidArgument,
+		},
+		{
+			Name:       "unknown coin",
+			Err:        errors.Wrap(NewNotFoundError([]string{"NOPE"}), "failed to process not existing titles"),
+			Code:       CodeNotFound,
+			HTTPStatus: http.StatusNotFound,
+			GRPCCode:   codes.NotFound,
+		},
+		{
+			Name:       "stale rate",
+			Err:        errors.Wrap(NewStaleRateError([]string{"ETH"}, nil), "failed to convert"),
+			Code:       CodeStaleRate,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			GRPCCode:   codes.FailedPrecondition,
+		},
+		{
+			Name:       "provider down",
+			Err:        NewProviderError("binance", nil, true, errors.New("status 503")),
+			Code:       CodeProvider,
+			HTTPStatus: http.StatusServiceUnavailable,
+			GRPCCode:   codes.Unavailable,
+		},
+		{
+			Name:       "provider bad response",
+			Err:        NewProviderError("binance", nil, false, errors.New("malformed response")),
+			Code:       CodeProvider,
+			HTTPStatus: http.StatusBadGateway,
+			GRPCCode:   codes.Internal,
+		},
+		{
+			Name:       "webhook rejected delivery",
+			Err:        NewDeliveryError(false, errors.New("status 410")),
+			Code:       CodeDelivery,
+			HTTPStatus: http.StatusBadGateway,
+			GRPCCode:   codes.Internal,
+		},
+		{
+			Name:       "storage failure",
+			Err:        NewStorageError("postgres", false, errors.New("syntax error")),
+			Code:       CodeStorage,
+			HTTPStatus: http.StatusInternalServerError,
+			GRPCCode:   codes.Internal,
+		},
+		{
+			Name:       "deadline",
+			Err:        NewStorageError("postgres", false, fmt.Errorf("query: %w", context.DeadlineExceeded)),
+			Code:       CodeStorage,
+			HTTPStatus: http.StatusGatewayTimeout,
+			GRPCCode:   codes.DeadlineExceeded,
+		},
+		{
+			Name:       "unknown error",
+			Err:        errors.New("boom"),
+			Code:       CodeInternal,
+			HTTPStatus: http.StatusInternalServerError,
+			GRPCCode:   codes.Internal,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Code, CodeOf(testCase.Err))
+			assert.Equal(t, testCase.HTTPStatus, HTTPStatus(testCase.Err))
+			assert.Equal(t, testCase.GRPCCode, GRPCCode(testCase.Err))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// EventType names what an Event reports.
+type EventType string
+
+const (
+	// EventRatesActualized carries the rates stored by an actualization.
+	EventRatesActualized EventType = "rates.actualized"
+	// EventAlertFired and EventAlertResolved carry an AlertEvent.
+	EventAlertFired    EventType = "alert.fired"
+	EventAlertResolved EventType = "alert.resolved"
+)
+
+// EventTypes lists every EventType.
+var EventTypes = []EventType{EventRatesActualized, EventAlertFired, EventAlertResolved}
+
+// Event is something the service announces to its subscribers.
+type Event struct {
+	// ID is unique, so that subscribers can drop duplicate deliveries.
+	ID   string
+	Type EventType
+	At   time.Time
+	// Rates are set for EventRatesActualized.
+	Rates []*Coin
+	// Alert is set for EventAlertFired and EventAlertResolved.
+	Alert *AlertEvent
+}
+
+// NewRatesEvent announces that coins were stored.
+func NewRatesEvent(coins []*Coin, at time.Time) *Event {
+	return &Event{ID: newEventID(), Type: EventRatesActualized, At: at, Rates: coins}
+}
+
+// NewAlertEvent announces that a rule fired or resolved.
+func NewAlertEvent(alert *AlertEvent) *Event {
+	eventType := EventAlertFired
+	if alert.State == AlertResolved {
+		eventType = EventAlertResolved
+	}
+
+	return &Event{ID: newEventID(), Type: eventType, At: alert.At, Alert: alert}
+}
+
+func newEventID() string {
+	var b [16]byte
+
+	// crypto/rand.Read never fails on supported platforms.
+	_, _ = rand.Read(b[:])
+
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Period bounds a history query, both ends inclusive. A zero From or To leaves
+// that end unbounded, so the zero Period covers the whole history.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+func NewPeriod(from, to time.Time) (Period, error) {
+	period := Period{
+		From: from,
+		To:   to,
+	}
+
+	if err := period.Validate(); err != nil {
+		return Period{}, err
+	}
+
+	return period, nil
+}
+
+// LookbackPeriod covers the last lookback up to now, e.g. the last 24 hours.
+func LookbackPeriod(lookback time.Duration, now time.Time) (Period, error) {
+	if lookback <= 0 {
+		return Period{}, errors.Wrap(ErrInvalidParam, "lookback must be positive")
+	}
+
+	return NewPeriod(now.Add(-lookback), now)
+}
+
+// ParsePeriod reads the period of a history query: either window, a duration
+// such as 24h looking back from now, or from and to, RFC 3339 times. Empty
+// values are not set.
+func ParsePeriod(from, to, window string, now time.Time) (Period, error) {
+	if window != "" {
+		if from != "" || to != "" {
+			return Period{}, errors.Wrap(ErrInvalidParam, "window cannot be combined with from or to")
+		}
+
+		lookback, err := time.ParseDuration(window)
+		if err != nil {
+			return Period{}, errors.Wrapf(ErrInvalidParam, "invalid window %q", window)
+		}
+
+		return LookbackPeriod(lookback, now)
+	}
+
+	var period Period
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		to    *time.Time
+	}{
+		{name: "from", value: from, to: &period.From},
+		{name: "to", value: to, to: &period.To},
+	} {
+		if bound.value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return Period{}, errors.Wrapf(ErrInvalidParam, "invalid %s %q, want RFC 3339", bound.name, bound.value)
+		}
+
+		*bound.to = t
+	}
+
+	return period, period.Validate()
+}
+
+func (p Period) Validate() error {
+	if !p.From.IsZero() && !p.To.IsZero() && p.From.After(p.To) {
+		return errors.Wrapf(ErrInvalidParam, "period start %s is after its end %s",
+			p.From.Format(time.RFC3339), p.To.Format(time.RFC3339))
+	}
+
+	if !p.From.IsZero() && p.From.After(time.Now()) {
+		return errors.Wrapf(ErrInvalidParam, "period start %s is in the future", p.From.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (p Period) Contains(t time.Time) bool {
+	if !p.From.IsZero() && t.Before(p.From) {
+		return false
+	}
+
+	if !p.To.IsZero() && t.After(p.To) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testTable := []struct {
+		name    string
+		from    time.Time
+		to      time.Time
+		wantErr bool
+	}{
+		{name: "unbounded"},
+		{name: "bounded", from: now.Add(-time.Hour), to: now},
+		{name: "open start", to: now},
+		{name: "open end", from: now.Add(-time.Hour)},
+		{name: "single instant", from: now.Add(-time.Hour), to: now.Add(-time.Hour)},
+		{name: "inverted", from: now, to: now.Add(-time.Hour), wantErr: true},
+		{name: "starts in the future", from: now.Add(time.Hour), wantErr: true},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			period, err := NewPeriod(tc.from, tc.to)
+
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidParam)
+				require.Equal(t, Period{}, period)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, Period{From: tc.from, To: tc.to}, period)
+		})
+	}
+}
+
+func TestLookbackPeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	period, err := LookbackPeriod(24*time.Hour, now)
+	require.NoError(t, err)
+	require.Equal(t, Period{From: now.Add(-24 * time.Hour), To: now}, period)
+
+	_, err = LookbackPeriod(0, now)
+	require.ErrorIs(t, err, ErrInvalidParam)
+
+	_, err = LookbackPeriod(-time.Hour, now)
+	require.ErrorIs(t, err, ErrInvalidParam)
+}
+
+func TestParsePeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name     string
+		from     string
+		to       string
+		window   string
+		expected Period
+		wantErr  bool
+	}{
+		{
+			name: "whole history",
+		},
+		{
+			name:     "window",
+			window:   "1h",
+			expected: Period{From: now.Add(-time.Hour), To: now},
+		},
+		{
+			name:     "from and to",
+			from:     "2024-01-01T10:00:00Z",
+			to:       "2024-01-01T11:00:00Z",
+			expected: Period{From: now.Add(-2 * time.Hour), To: now.Add(-time.Hour)},
+		},
+		{
+			name:    "window with from",
+			from:    "2024-01-01T10:00:00Z",
+			window:  "1h",
+			wantErr: true,
+		},
+		{
+			name:    "malformed window",
+			window:  "an hour",
+			wantErr: true,
+		},
+		{
+			name:    "zero window",
+			window:  "0s",
+			wantErr: true,
+		},
+		{
+			name:    "malformed to",
+			to:      "yesterday",
+			wantErr: true,
+		},
+		{
+			name:    "from after to",
+			from:    "2024-01-01T11:00:00Z",
+			to:      "2024-01-01T10:00:00Z",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			period, err := ParsePeriod(tc.from, tc.to, tc.window, now)
+
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidParam)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, period)
+		})
+	}
+}
+
+func TestPeriodContains(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	period := Period{From: base, To: base.Add(time.Hour)}
+
+	require.True(t, period.Contains(base))
+	require.True(t, period.Contains(base.Add(time.Hour)))
+	require.False(t, period.Contains(base.Add(-time.Nanosecond)))
+	require.False(t, period.Contains(base.Add(time.Hour+time.Nanosecond)))
+
+	require.True(t, Period{}.Contains(base))
+	require.True(t, Period{To: base}.Contains(base.Add(-24*time.Hour)))
+	require.False(t, Period{From: base}.Contains(base.Add(-time.Second)))
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Holding is a quantity of a title held in a portfolio.
+type Holding struct {
+	Title    string
+	Quantity decimal.Decimal
+}
+
+// Valuation is the value of a portfolio in Quote.
+type Valuation struct {
+	Quote string
+	Total decimal.Decimal
+	// Change24h is the relative change of Total over the last 24 hours, 0.05
+	// meaning +5%. It is nil unless every asset has a Change24h.
+	Change24h *decimal.Decimal
+	// Assets are in the order of the valued holdings.
+	Assets []AssetValuation
+}
+
+// AssetValuation is the part of a Valuation held in a single title.
+type AssetValuation struct {
+	Title    string
+	Quantity decimal.Decimal
+	// Cost is the latest rate of Title, actual at ActualAt.
+	Cost     decimal.Decimal
+	Value    decimal.Decimal
+	ActualAt time.Time
+	// Weight is the share of Value in the portfolio total.
+	Weight decimal.Decimal
+	// Change24h is the relative change of Cost over the last 24 hours. It is
+	// nil when no rate of Title is stored from about 24 hours ago: an older
+	// rate would measure a longer change.
+	Change24h *decimal.Decimal
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Webhook is a URL events are POSTed to, signed with Secret.
+type Webhook struct {
+	ID     int64
+	URL    string
+	Secret string
+	// Events the webhook subscribes to, every type when empty.
+	Events    []EventType
+	CreatedAt time.Time
+}
+
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Wrapf(ErrInvalidParam, "invalid url %q", w.URL)
+	}
+
+	if w.Secret == "" {
+		return errors.Wrap(ErrInvalidParam, "secret cannot be empty")
+	}
+
+	for _, eventType := range w.Events {
+		if !slices.Contains(EventTypes, eventType) {
+			return errors.Wrapf(ErrInvalidParam, "unknown event type %q", eventType)
+		}
+	}
+
+	return nil
+}
+
+// Subscribes reports whether events of eventType are delivered to w.
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// Delivery is an event encoded for a webhook.
+type Delivery struct {
+	EventID   string
+	EventType EventType
+	Payload   []byte
+}
+
+// DeadLetter is a delivery that failed permanently or ran out of attempts.
+// It can be replayed.
+type DeadLetter struct {
+	ID        int64
+	WebhookID int64
+	Delivery  Delivery
+	Attempts  int
+	LastError string
+	FailedAt  time.Time
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookValidate(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name    string
+		webhook Webhook
+		wantErr bool
+	}{
+		{name: "every event", webhook: Webhook{URL: "https://example.com/hook", Secret: "s3cret"}},
+		{name: "some events", webhook: Webhook{URL: "http://localhost:8080", Secret: "s3cret", Events: []EventType{EventAlertFired}}},
+		{name: "relative url", webhook: Webhook{URL: "/hook", Secret: "s3cret"}, wantErr: true},
+		{name: "unsupported scheme", webhook: Webhook{URL: "ftp://example.com", Secret: "s3cret"}, wantErr: true},
+		{name: "empty secret", webhook: Webhook{URL: "https://example.com/hook"}, wantErr: true},
+		{name: "unknown event", webhook: Webhook{URL: "https://example.com/hook", Secret: "s3cret", Events: []EventType{"rates.deleted"}}, wantErr: true},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.webhook.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidParam)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookSubscribes(t *testing.T) {
+	t.Parallel()
+
+	every := Webhook{}
+	require.True(t, every.Subscribes(EventRatesActualized))
+	require.True(t, every.Subscribes(EventAlertResolved))
+
+	alerts := Webhook{Events: []EventType{EventAlertFired}}
+	require.True(t, alerts.Subscribes(EventAlertFired))
+	require.False(t, alerts.Subscribes(EventRatesActualized))
+}
+
+func TestNewAlertEvent(t *testing.T) {
+	t.Parallel()
+
+	fired := NewAlertEvent(&AlertEvent{State: AlertFiring})
+	resolved := NewAlertEvent(&AlertEvent{State: AlertResolved})
+
+	require.Equal(t, EventAlertFired, fired.Type)
+	require.Equal(t, EventAlertResolved, resolved.Type)
+	require.Len(t, fired.ID, 32)
+	require.NotEqual(t, fired.ID, resolved.ID)
+}
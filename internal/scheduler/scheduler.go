@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"crypto-project/internal/entities"
+)
+
+// ErrAlreadyRunning is returned by RunNow while another run is in progress.
+var ErrAlreadyRunning = errors.New("job is already running")
+
+type Job func(ctx context.Context) error
+
+type Config struct {
+	Interval time.Duration
+	// Jitter adds a random delay in [0, Jitter) before every scheduled run.
+	Jitter time.Duration
+	// RunTimeout bounds a single run, zero means no limit besides Run's context.
+	RunTimeout time.Duration
+	// OnRunFinished is called after every run with the updated status and
+	// the error the run returned.
+	OnRunFinished func(status Status, err error)
+}
+
+type Status struct {
+	Running       bool
+	LastStartedAt time.Time
+	LastSuccessAt time.Time
+	LastErrorAt   time.Time
+	LastError     error
+	LastDuration  time.Duration
+	Runs          uint64
+	Failures      uint64
+	// Skipped counts the ticks missed because a run outlasted the interval.
+	Skipped uint64
+}
+
+// Scheduler runs a job periodically. Runs never overlap: when a run outlasts
+// the interval the missed ticks are skipped rather than queued.
+type Scheduler struct {
+	job Job
+	cfg Config
+
+	mu     sync.Mutex
+	status Status
+}
+
+func New(job Job, cfg Config) (*Scheduler, error) {
+	if job == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "job not set")
+	}
+
+	if cfg.Interval <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "interval must be positive")
+	}
+
+	if cfg.Jitter < 0 || cfg.RunTimeout < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "jitter and run timeout cannot be negative")
+	}
+
+	return &Scheduler{
+		job: job,
+		cfg: cfg,
+	}, nil
+}
+
+// Run runs the job right away and then every interval until ctx is done.
+// Cancelling ctx also cancels the run in progress.
+func (s *Scheduler) Run(ctx context.Context) {
+	next := time.Now()
+
+	for {
+		if err := s.RunNow(ctx); errors.Is(err, ErrAlreadyRunning) {
+			s.skip(1)
+		}
+
+		next = next.Add(s.cfg.Interval)
+
+		if now := time.Now(); now.After(next) {
+			missed := now.Sub(next)/s.cfg.Interval + 1
+			next = next.Add(missed * s.cfg.Interval)
+			s.skip(uint64(missed))
+		}
+
+		timer := time.NewTimer(time.Until(next) + s.jitter())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// RunNow runs the job immediately unless it is already running.
+func (s *Scheduler) RunNow(ctx context.Context) error {
+	s.mu.Lock()
+	if s.status.Running {
+		s.mu.Unlock()
+		return ErrAlreadyRunning
+	}
+
+	startedAt := time.Now()
+	s.status.Running = true
+	s.status.LastStartedAt = startedAt
+	s.mu.Unlock()
+
+	if s.cfg.RunTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.RunTimeout)
+		defer cancel()
+	}
+
+	err := s.job(ctx)
+	finishedAt := time.Now()
+
+	s.mu.Lock()
+	s.status.Running = false
+	s.status.Runs++
+	s.status.LastDuration = finishedAt.Sub(startedAt)
+
+	if err != nil {
+		s.status.Failures++
+		s.status.LastError = err
+		s.status.LastErrorAt = finishedAt
+	} else {
+		s.status.LastSuccessAt = finishedAt
+	}
+
+	status := s.status
+	s.mu.Unlock()
+
+	if s.cfg.OnRunFinished != nil {
+		s.cfg.OnRunFinished(status, err)
+	}
+
+	return err
+}
+
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+func (s *Scheduler) skip(n uint64) {
+	s.mu.Lock()
+	s.status.Skipped += n
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) jitter() time.Duration {
+	if s.cfg.Jitter <= 0 {
+		return 0
+	}
+
+	return rand.N(s.cfg.Jitter)
+}
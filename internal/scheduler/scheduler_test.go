@@ -0,0 +1,201 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"crypto-project/internal/entities"
+	"crypto-project/internal/scheduler"
+)
+
+func TestRunPeriodically(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+
+	ran := make(chan struct{})
+
+	s, err := scheduler.New(func(ctx context.Context) error {
+		runs.Add(1)
+
+		select {
+		case ran <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		return nil
+	}, scheduler.Config{Interval: time.Millisecond, Jitter: time.Millisecond})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	for range 3 {
+		<-ran
+	}
+
+	cancel()
+	<-done
+
+	require.GreaterOrEqual(t, runs.Load(), int32(3))
+
+	status := s.Status()
+	require.EqualValues(t, runs.Load(), status.Runs)
+	require.Zero(t, status.Failures)
+	require.False(t, status.Running)
+	require.False(t, status.LastSuccessAt.IsZero())
+}
+
+func TestRunNeverOverlaps(t *testing.T) {
+	t.Parallel()
+
+	var runs, active, maxActive atomic.Int32
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	s, err := scheduler.New(func(ctx context.Context) error {
+		runs.Add(1)
+
+		n := active.Add(1)
+		defer active.Add(-1)
+
+		if n > maxActive.Load() {
+			maxActive.Store(n)
+		}
+
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+
+		return nil
+	}, scheduler.Config{Interval: time.Millisecond})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	manual := make(chan error)
+
+	go func() {
+		manual <- s.RunNow(ctx)
+	}()
+
+	<-started
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	// While a run is held, the scheduled ones and RunNow are turned away.
+	require.ErrorIs(t, s.RunNow(ctx), scheduler.ErrAlreadyRunning)
+	require.Eventually(t, func() bool { return s.Status().Skipped > 0 }, 5*time.Second, time.Millisecond)
+	require.EqualValues(t, 1, runs.Load())
+
+	close(release)
+	require.NoError(t, <-manual)
+
+	cancel()
+	<-done
+
+	require.EqualValues(t, 1, maxActive.Load())
+	require.EqualValues(t, runs.Load(), s.Status().Runs)
+}
+
+func TestRunTimeout(t *testing.T) {
+	t.Parallel()
+
+	s, err := scheduler.New(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, scheduler.Config{Interval: time.Hour, RunTimeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = s.RunNow(context.Background())
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+
+	status := s.Status()
+	require.EqualValues(t, 1, status.Failures)
+	require.ErrorIs(t, status.LastError, context.DeadlineExceeded)
+	require.GreaterOrEqual(t, status.LastDuration, 20*time.Millisecond)
+}
+
+func TestStatusReporting(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+	fail := true
+
+	var (
+		reported    []scheduler.Status
+		reportedErr []error
+	)
+
+	s, err := scheduler.New(func(ctx context.Context) error {
+		if fail {
+			return errBoom
+		}
+
+		return nil
+	}, scheduler.Config{
+		Interval: time.Hour,
+		OnRunFinished: func(status scheduler.Status, err error) {
+			reported = append(reported, status)
+			reportedErr = append(reportedErr, err)
+		},
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, s.RunNow(context.Background()), errBoom)
+
+	fail = false
+	require.NoError(t, s.RunNow(context.Background()))
+
+	status := s.Status()
+	require.EqualValues(t, 2, status.Runs)
+	require.EqualValues(t, 1, status.Failures)
+	require.ErrorIs(t, status.LastError, errBoom)
+	require.False(t, status.LastSuccessAt.Before(status.LastErrorAt))
+
+	require.Equal(t, []error{errBoom, nil}, reportedErr)
+	require.Len(t, reported, 2)
+	require.EqualValues(t, 1, reported[0].Runs)
+	require.Equal(t, status, reported[1])
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	job := func(ctx context.Context) error { return nil }
+
+	_, err := scheduler.New(nil, scheduler.Config{Interval: time.Second})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = scheduler.New(job, scheduler.Config{})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = scheduler.New(job, scheduler.Config{Interval: time.Second, Jitter: -time.Second})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}